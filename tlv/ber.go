@@ -0,0 +1,151 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
+	"github.com/pauloavelar/go-tlv/tlv/internal/utils"
+)
+
+const (
+	berConstructedBit   = 0x20 // first tag byte flag for nested TLV values
+	berTagNumberMask    = 0x1f // first tag byte bits that announce subsequent tag bytes
+	berContinuationBit  = 0x80 // subsequent tag byte flag for more tag bytes
+	berLongLengthBit    = 0x80 // first length byte flag for the long form
+	berLengthBytesMask  = 0x7f // first length byte bits with the number of length bytes
+	berIndefiniteLength = 0x80 // first length byte for the (unsupported) indefinite form
+	berMinHeaderSize    = 2
+	bitsPerByte         = 8
+)
+
+var (
+	errBERTagTooLong       = errors.New("tag is longer than 8 bytes")
+	errBERLengthTooLong    = errors.New("length is longer than 8 bytes")
+	errBERIndefiniteLength = errors.New("indefinite length is not supported")
+)
+
+// berHeader reads and writes BER-TLV (ISO/IEC 8825) tags and lengths.
+type berHeader struct{}
+
+// CreateBERDecoder creates a [Decoder] for BER-TLV (ISO/IEC 8825) messages, such as EMV data.
+// Tags take as many bytes as the 0x1F continuation scheme requires and keep all of them
+// (e.g. 0x9F02), lengths use the short and long forms (0x81, 0x82...) and values are big endian.
+// Nodes with the constructed bit set in their tag report it through [Node.IsConstructed].
+func CreateBERDecoder() Decoder {
+	return newDecoder(&berHeader{}, binary.BigEndian)
+}
+
+func (h *berHeader) read(data []byte) (tag Tag, length uint64, size int, err error) {
+	tag, tagSize, err := h.readTag(data)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	length, lengthSize, err := h.readLength(data[tagSize:])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return tag, length, tagSize + lengthSize, nil
+}
+
+func (*berHeader) readTag(data []byte) (tag Tag, size int, err error) {
+	if len(data) == 0 {
+		return 0, 0, errIncompleteHeader
+	}
+
+	if data[0]&berTagNumberMask != berTagNumberMask {
+		return Tag(data[0]), 1, nil
+	}
+
+	value := uint64(data[0])
+	for i := 1; i < len(data); i++ {
+		if i == sizes.Uint64 {
+			return 0, 0, errBERTagTooLong
+		}
+
+		value = value<<bitsPerByte | uint64(data[i])
+		if data[i]&berContinuationBit == 0 {
+			return Tag(value), i + 1, nil
+		}
+	}
+
+	return 0, 0, errIncompleteHeader
+}
+
+func (*berHeader) readLength(data []byte) (length uint64, size int, err error) {
+	if len(data) == 0 {
+		return 0, 0, errIncompleteHeader
+	}
+
+	first := data[0]
+	if first&berLongLengthBit == 0 {
+		return uint64(first), 1, nil
+	}
+
+	if first == berIndefiniteLength {
+		return 0, 0, errBERIndefiniteLength
+	}
+
+	count := int(first & berLengthBytesMask)
+	if count > sizes.Uint64 {
+		return 0, 0, errBERLengthTooLong
+	}
+
+	if len(data) <= count {
+		return 0, 0, errIncompleteHeader
+	}
+
+	for _, b := range data[1 : count+1] {
+		length = length<<bitsPerByte | uint64(b)
+	}
+
+	return length, count + 1, nil
+}
+
+func (h *berHeader) append(dst []byte, tag Tag, length uint64) ([]byte, error) {
+	start := len(dst)
+	dst = appendBigEndian(dst, uint64(tag), utils.ByteCount(uint64(tag)))
+
+	if parsed, size, err := h.readTag(dst[start:]); err != nil || parsed != tag || size != len(dst)-start {
+		return nil, &InvalidTagError{Tag: tag}
+	}
+
+	if length < berLongLengthBit {
+		return append(dst, byte(length)), nil
+	}
+
+	count := utils.ByteCount(length)
+	dst = append(dst, berLongLengthBit|byte(count))
+
+	return appendBigEndian(dst, length, count), nil
+}
+
+func (*berHeader) size(tag Tag, length uint64) int {
+	size := utils.ByteCount(uint64(tag)) + 1
+	if length >= berLongLengthBit {
+		size += utils.ByteCount(length)
+	}
+
+	return size
+}
+
+func (*berHeader) minSize() int {
+	return berMinHeaderSize
+}
+
+func (*berHeader) isConstructed(tag Tag) bool {
+	first := uint64(tag) >> (bitsPerByte * (utils.ByteCount(uint64(tag)) - 1))
+
+	return first&berConstructedBit != 0
+}
+
+// appendBigEndian appends the last count bytes of the value, most significant first.
+func appendBigEndian(dst []byte, value uint64, count int) []byte {
+	for i := count - 1; i >= 0; i-- {
+		dst = append(dst, byte(value>>(bitsPerByte*i)))
+	}
+
+	return dst
+}
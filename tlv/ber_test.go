@@ -0,0 +1,158 @@
+package tlv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// berData is an EMV "File Control Information" template:
+//
+//	6F (FCI template, constructed)
+//	  84 (DF name): 1PAY.SYS.DDF01
+//	  A5 (FCI proprietary template, constructed)
+//	    88 (SFI): 2
+//	    5F2D (language preference): en
+var berData = []byte{
+	0x6f, 0x1a,
+	0x84, 0x0e, 0x31, 0x50, 0x41, 0x59, 0x2e, 0x53, 0x59, 0x53, 0x2e, 0x44, 0x44, 0x46, 0x30, 0x31,
+	0xa5, 0x08,
+	0x88, 0x01, 0x02,
+	0x5f, 0x2d, 0x02, 0x65, 0x6e,
+}
+
+func TestCreateBERDecoder(t *testing.T) {
+	d := CreateBERDecoder()
+
+	nodes, err := d.DecodeBytes(berData)
+
+	require.Nil(t, err)
+	require.Equal(t, 1, len(nodes))
+	require.Equal(t, Tag(0x6f), nodes[0].Tag)
+	require.Equal(t, Length(26), nodes[0].Length)
+	require.True(t, nodes[0].IsConstructed())
+
+	children, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(children))
+	require.Equal(t, "1PAY.SYS.DDF01", children[0].GetString())
+	require.False(t, children[0].IsConstructed())
+	require.True(t, children[1].IsConstructed())
+
+	proprietary, err := children[1].GetNodes()
+	require.Nil(t, err)
+	require.Equal(t, uint8(2), proprietary.GetByTag(0x88)[0].GetPaddedUint8())
+
+	language, ok := proprietary.GetFirstByTag(0x5f2d)
+	require.True(t, ok)
+	require.Equal(t, "en", language.GetString())
+	require.False(t, language.IsConstructed())
+}
+
+func TestCreateBERDecoder_RoundTrip(t *testing.T) {
+	d := CreateBERDecoder()
+	nodes, err := d.DecodeBytes(berData)
+	require.Nil(t, err)
+
+	res, err := d.GetEncoder().EncodeNodes(nodes)
+
+	require.Nil(t, err)
+	require.Equal(t, berData, res)
+}
+
+func TestCreateBERDecoder_WithLongFormLengths(t *testing.T) {
+	d := CreateBERDecoder()
+	node := d.NewNode(0x9f02, make([]byte, 300))
+
+	encoded, err := d.GetEncoder().EncodeNode(node)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x9f, 0x02, 0x82, 0x01, 0x2c}, encoded[:5])
+
+	res, read, err := d.DecodeSingle(encoded)
+
+	require.Nil(t, err)
+	require.Equal(t, uint64(305), read)
+	require.Equal(t, Tag(0x9f02), res.Tag)
+	require.Equal(t, Length(300), res.Length)
+
+	constructed := d.NewNode(0xbf0c, nil)
+	require.True(t, constructed.IsConstructed())
+}
+
+func TestCreateBERDecoder_WhenTheHeaderIsMalformed(t *testing.T) {
+	scenarios := map[string][]byte{
+		"indefinite length is not supported": {0x6f, 0x80, 0x00, 0x00},
+		"length is longer than 8 bytes":      {0x6f, 0x89, 0x00},
+		"tag is longer than 8 bytes":         {0x1f, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x01, 0x00},
+	}
+
+	for reason, data := range scenarios {
+		_, _, err := CreateBERDecoder().DecodeSingle(data)
+
+		var malformed *MalformedHeaderError
+		require.True(t, errors.As(err, &malformed))
+		require.Equal(t, reason, malformed.Reason)
+	}
+}
+
+func TestCreateBERDecoder_WhenTheHeaderIsIncomplete(t *testing.T) {
+	scenarios := [][]byte{{}, {0x6f}, {0x9f, 0x82}, {0x6f, 0x82, 0x01}}
+
+	for _, data := range scenarios {
+		_, _, err := CreateBERDecoder().DecodeSingle(data)
+
+		require.True(t, errors.Is(err, ErrMessageTooShort))
+	}
+}
+
+func TestCreateBERDecoder_WhenTheTagIsInvalid(t *testing.T) {
+	d := CreateBERDecoder()
+
+	for _, tag := range []Tag{0x1f, 0x9f82, 0x9f0201} {
+		_, err := d.GetEncoder().EncodeNode(d.NewNode(tag, nil))
+
+		require.True(t, errors.Is(err, ErrInvalidTag))
+	}
+}
+
+func TestCreateBERDecoder_Scanner(t *testing.T) {
+	scanner := CreateBERDecoder().NewScanner(bytes.NewReader(append(append([]byte{}, berData...), berData...)))
+
+	count := 0
+	for scanner.Scan() {
+		require.Equal(t, berData, scanner.Node().Raw)
+		count++
+	}
+
+	require.Nil(t, scanner.Err())
+	require.Equal(t, 2, count)
+}
+
+func TestCreateBERDecoder_ScannerWhenTheHeaderIsMalformed(t *testing.T) {
+	scanner := CreateBERDecoder().NewScanner(bytes.NewReader([]byte{0x9f, 0x02, 0x80}))
+
+	require.False(t, scanner.Scan())
+	require.True(t, errors.Is(scanner.Err(), ErrMalformedHeader))
+}
+
+func TestCreateBERDecoder_ScannerWhenTheHeaderIsTruncated(t *testing.T) {
+	scanner := CreateBERDecoder().NewScanner(bytes.NewReader([]byte{0x9f, 0x02, 0x82, 0x01}))
+
+	require.False(t, scanner.Scan())
+	require.True(t, errors.Is(scanner.Err(), ErrMessageTooShort))
+}
+
+func TestBERDecoder_DecodeSingle_DoesNotAllocate(t *testing.T) {
+	input := []byte{0x9f, 0x02, 0x82, 0x00, 0x01, 0xff}
+	decoder := CreateBERDecoder()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, _, err := decoder.DecodeSingle(input); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	require.Zero(t, allocs)
+}
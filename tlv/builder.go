@@ -0,0 +1,218 @@
+package tlv
+
+import (
+	"math"
+	"time"
+
+	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
+	"github.com/pauloavelar/go-tlv/tlv/internal/utils"
+)
+
+// Builder builds TLV messages node by node with the configuration of a [Decoder], computing the
+// lengths of nested nodes automatically. Containers are opened with Begin and closed with End.
+//
+// Methods can be chained: the first error (e.g. a tag that does not fit the tag size) is kept,
+// the following calls are ignored and the error is returned by Err, Bytes and Nodes.
+// Numeric values are encoded with the size of their type, as in [Encoder.Marshal].
+type Builder struct {
+	decoder *decoder
+	buf     []byte
+	open    []openContainer
+	err     error
+}
+
+// openContainer is a node started with Begin, whose header was reserved at the given position of the buffer
+// and whose value starts right after it.
+type openContainer struct {
+	tag    Tag
+	header int
+	start  int
+}
+
+// NewBuilder creates a [Builder] for messages with the decoder configuration.
+func (d *decoder) NewBuilder() *Builder {
+	return &Builder{decoder: d}
+}
+
+// Begin starts a node whose value holds the nodes added until the matching End.
+// Its header is reserved right away and written in place by End.
+func (b *Builder) Begin(tag Tag) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	header := len(b.buf)
+	if b.buf, b.err = b.decoder.header.append(b.buf, tag, 0); b.err == nil {
+		b.open = append(b.open, openContainer{tag: tag, header: header, start: len(b.buf)})
+	}
+
+	return b
+}
+
+// End finishes the node started by the last Begin, writing its header before the nested nodes.
+func (b *Builder) End() *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	if len(b.open) == 0 {
+		b.err = &UnbalancedBuilderError{schema: b.decoder.schema}
+		return b
+	}
+
+	container := b.open[len(b.open)-1]
+	b.open = b.open[:len(b.open)-1]
+
+	length := uint64(len(b.buf) - container.start)
+	size := b.decoder.header.size(container.tag, length)
+
+	// BER headers take more bytes for long lengths, so only then the value is moved after the header.
+	if extra := size - (container.start - container.header); extra > 0 {
+		b.buf = append(b.buf, make([]byte, extra)...)
+		copy(b.buf[container.start+extra:], b.buf[container.start:container.start+int(length)])
+	}
+
+	if _, err := b.decoder.header.append(b.buf[container.header:container.header], container.tag, length); err != nil {
+		b.err = err
+	}
+
+	return b
+}
+
+// Value adds a node with the value as is.
+func (b *Builder) Value(tag Tag, value []byte) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	if b.buf, b.err = b.decoder.header.append(b.buf, tag, uint64(len(value))); b.err == nil {
+		b.buf = append(b.buf, value...)
+	}
+
+	return b
+}
+
+// Node adds existing nodes, taking the lengths from their values.
+func (b *Builder) Node(nodes ...Node) *Builder {
+	for i := range nodes {
+		b.Value(nodes[i].Tag, nodes[i].Value)
+	}
+
+	return b
+}
+
+// String adds a node with the text as UTF-8.
+func (b *Builder) String(tag Tag, value string) *Builder {
+	return b.Value(tag, []byte(value))
+}
+
+// Bool adds a node with a 1-byte boolean.
+func (b *Builder) Bool(tag Tag, value bool) *Builder {
+	if value {
+		return b.uint(tag, 1, sizes.Bool)
+	}
+
+	return b.uint(tag, 0, sizes.Bool)
+}
+
+// Uint8 adds a node with a 1-byte unsigned integer.
+func (b *Builder) Uint8(tag Tag, value uint8) *Builder {
+	return b.uint(tag, uint64(value), sizes.Uint8)
+}
+
+// Uint16 adds a node with a 2-byte unsigned integer.
+func (b *Builder) Uint16(tag Tag, value uint16) *Builder {
+	return b.uint(tag, uint64(value), sizes.Uint16)
+}
+
+// Uint32 adds a node with a 4-byte unsigned integer.
+func (b *Builder) Uint32(tag Tag, value uint32) *Builder {
+	return b.uint(tag, uint64(value), sizes.Uint32)
+}
+
+// Uint64 adds a node with an 8-byte unsigned integer.
+func (b *Builder) Uint64(tag Tag, value uint64) *Builder {
+	return b.uint(tag, value, sizes.Uint64)
+}
+
+// Int8 adds a node with a 1-byte signed integer.
+func (b *Builder) Int8(tag Tag, value int8) *Builder {
+	return b.uint(tag, uint64(uint8(value)), sizes.Int8)
+}
+
+// Int16 adds a node with a 2-byte signed integer.
+func (b *Builder) Int16(tag Tag, value int16) *Builder {
+	return b.uint(tag, uint64(uint16(value)), sizes.Int16)
+}
+
+// Int32 adds a node with a 4-byte signed integer.
+func (b *Builder) Int32(tag Tag, value int32) *Builder {
+	return b.uint(tag, uint64(uint32(value)), sizes.Int32)
+}
+
+// Int64 adds a node with an 8-byte signed integer.
+func (b *Builder) Int64(tag Tag, value int64) *Builder {
+	return b.uint(tag, uint64(value), sizes.Int64)
+}
+
+// Float32 adds a node with a 4-byte IEEE 754 float.
+func (b *Builder) Float32(tag Tag, value float32) *Builder {
+	return b.uint(tag, uint64(math.Float32bits(value)), sizes.Float32)
+}
+
+// Float64 adds a node with an 8-byte IEEE 754 float.
+func (b *Builder) Float64(tag Tag, value float64) *Builder {
+	return b.uint(tag, math.Float64bits(value), sizes.Float64)
+}
+
+// Time adds a node with the time as Unix seconds in 8 bytes.
+func (b *Builder) Time(tag Tag, value time.Time) *Builder {
+	return b.uint(tag, uint64(value.Unix()), sizes.Uint64)
+}
+
+func (b *Builder) uint(tag Tag, value uint64, size int) *Builder {
+	var buf [sizes.Uint64]byte
+	utils.PutPaddedUint64(b.decoder.byteOrder, buf[:size], value)
+
+	return b.Value(tag, buf[:size])
+}
+
+// Err returns the first error found while building the message.
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// Bytes returns the encoded message, which references the builder buffer and is overwritten after Reset.
+// It fails if any error was found or if there are nodes started with Begin and not ended.
+func (b *Builder) Bytes() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if len(b.open) > 0 {
+		open := make([]Tag, len(b.open))
+		for i := range b.open {
+			open[i] = b.open[i].tag
+		}
+
+		return nil, &UnbalancedBuilderError{Open: open, schema: b.decoder.schema}
+	}
+
+	return b.buf, nil
+}
+
+// Nodes returns the message decoded as [Nodes] with the decoder, including its schema validation.
+// Like the result of Bytes, the nodes reference the builder buffer unless they are cloned.
+func (b *Builder) Nodes() (Nodes, error) {
+	data, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.decoder.DecodeBytes(data)
+}
+
+// Reset clears the builder, keeping its buffer capacity for the next message.
+func (b *Builder) Reset() {
+	b.buf, b.open, b.err = b.buf[:0], b.open[:0], nil
+}
@@ -0,0 +1,167 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestData(builder *Builder) *Builder {
+	return builder.
+		Begin(tagMessage).
+		Begin(tagPushNotification).
+		String(tagTitle, "Hello there!").
+		Value(tagActionID, []byte{0xbc, 0x61, 0x4e}).
+		Uint32(tagTimestamp, 1625067296).
+		End().
+		Begin(tagPushNotification).
+		String(tagTitle, "You there?").
+		Uint8(tagActionID, 240).
+		Bool(tagSilent, true).
+		Uint32(tagTimestamp, 1614571323).
+		End().
+		End()
+}
+
+func TestBuilder_Bytes(t *testing.T) {
+	res, err := buildTestData(NewBuilder()).Bytes()
+
+	require.Nil(t, err)
+	require.Equal(t, data, res)
+}
+
+func TestBuilder_Nodes(t *testing.T) {
+	expected, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	nodes, err := buildTestData(NewBuilder()).Nodes()
+
+	require.Nil(t, err)
+	require.Equal(t, expected, nodes)
+}
+
+func TestBuilder_TypedValues(t *testing.T) {
+	date := time.Date(2021, 6, 30, 12, 34, 56, 0, time.UTC)
+
+	for _, byteOrder := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		nodes, err := MustCreateDecoder(1, 1, byteOrder).NewBuilder().
+			Int8(0x01, -2).
+			Int16(0x02, -300).
+			Int32(0x03, -70000).
+			Int64(0x04, -1).
+			Uint16(0x05, 0xabcd).
+			Uint64(0x06, 1<<40).
+			Float32(0x07, 1.5).
+			Float64(0x08, 3.14).
+			Time(0x09, date).
+			Node(NewNode(0x0a, []byte{0xff})).
+			Nodes()
+		require.Nil(t, err)
+
+		require.Equal(t, int8(-2), nodes[0].GetPaddedInt8())
+		require.Equal(t, int16(-300), nodes[1].GetPaddedInt16())
+		require.Equal(t, int32(-70000), nodes[2].GetPaddedInt32())
+		require.Equal(t, int64(-1), nodes[3].GetPaddedInt64())
+		require.Equal(t, uint16(0xabcd), nodes[4].GetPaddedUint16())
+		require.Equal(t, uint64(1<<40), nodes[5].GetPaddedUint64())
+
+		f32, _ := nodes[6].GetFloat32()
+		f64, _ := nodes[7].GetFloat64()
+		res, _ := nodes[8].GetDate()
+		require.Equal(t, float32(1.5), f32)
+		require.Equal(t, 3.14, f64)
+		require.Equal(t, date, res)
+		require.Equal(t, []byte{0xff}, nodes[9].Value)
+	}
+}
+
+func TestBuilder_WithBER(t *testing.T) {
+	value := make([]byte, 200)
+
+	nodes, err := CreateBERDecoder().NewBuilder().Begin(0x21).Value(0x9f02, value).End().Nodes()
+	require.Nil(t, err)
+
+	require.Equal(t, Length(204), nodes[0].Length)
+
+	children, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+	require.Equal(t, value, children[0].Value)
+}
+
+func TestBuilder_WithBERWhenNestedLengthsGrow(t *testing.T) {
+	value := make([]byte, 200)
+
+	res, err := CreateBERDecoder().NewBuilder().Begin(0x21).Begin(0x22).Value(0x9f02, value).End().End().Bytes()
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x21, 0x81, 0xcf, 0x22, 0x81, 0xcc, 0x9f, 0x02, 0x81, 0xc8}, res[:10])
+	require.Len(t, res, 210)
+}
+
+func TestBuilder_WithDeepNesting(t *testing.T) {
+	const depth = 1000
+
+	builder := NewBuilder()
+	for i := 0; i < depth; i++ {
+		builder.Begin(tagMessage)
+	}
+	for i := 0; i < depth; i++ {
+		builder.End()
+	}
+
+	nodes, err := builder.Nodes()
+	require.Nil(t, err)
+	require.Equal(t, Length(4*depth-4), nodes[0].Length)
+}
+
+func TestBuilder_WhenEndHasNoBegin(t *testing.T) {
+	builder := NewBuilder().End().String(tagTitle, "ignored")
+
+	_, err := builder.Bytes()
+
+	require.True(t, errors.Is(err, ErrUnbalancedBuilder))
+	require.Equal(t, err, builder.Err())
+}
+
+func TestBuilder_WhenNodesAreNotEnded(t *testing.T) {
+	_, err := NewBuilder().Begin(tagMessage).Begin(tagPushNotification).Nodes()
+
+	require.Equal(t, "unbalanced builder, nodes not ended: 0x1/0x101", err.Error())
+}
+
+func TestBuilder_WhenTheTagOverflows(t *testing.T) {
+	builder := MustCreateDecoder(1, 1, binary.BigEndian).NewBuilder().Uint8(0x100, 1).Uint8(0x01, 1)
+
+	var overflowErr *OverflowError
+	require.True(t, errors.As(builder.Err(), &overflowErr))
+	require.Equal(t, fieldTag, overflowErr.Field)
+
+	_, err := builder.Bytes()
+	require.Equal(t, overflowErr, err)
+}
+
+func TestBuilder_WhenTheContainerTagOverflows(t *testing.T) {
+	builder := MustCreateDecoder(1, 1, binary.BigEndian).NewBuilder().Begin(0x100)
+
+	require.True(t, errors.Is(builder.Err(), ErrOverflow))
+}
+
+func TestBuilder_WhenTheContainerLengthOverflows(t *testing.T) {
+	builder := MustCreateDecoder(1, 1, binary.BigEndian).NewBuilder()
+
+	_, err := builder.Begin(0x01).Value(0x02, make([]byte, 254)).End().Bytes()
+
+	require.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestBuilder_Reset(t *testing.T) {
+	builder := NewBuilder().Begin(tagMessage).End().End()
+
+	builder.Reset()
+	res, err := buildTestData(builder).Bytes()
+
+	require.Nil(t, err)
+	require.Equal(t, data, res)
+}
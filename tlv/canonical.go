@@ -0,0 +1,104 @@
+package tlv
+
+import (
+	"sort"
+
+	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
+	"github.com/pauloavelar/go-tlv/tlv/internal/utils"
+)
+
+// CanonicalOptions selects the normalizations applied by [Nodes.Canonicalize].
+// Without a schema or BER constructed tags no value is nested, so SortByTag only sorts the root nodes.
+type CanonicalOptions struct {
+	SortByTag       bool // Sorts siblings by tag, keeping repeated tags in their original order.
+	MinimalIntegers bool // Encodes values typed by the schema as uint, int or date with as few bytes as allowed.
+}
+
+// Canonicalize returns a copy of the nodes in canonical form, so logically identical messages from
+// different producers have the same bytes, and the same hashes once encoded with EncodeNodes.
+// Non-empty values of nested nodes (see [Node.IsNested]) are canonicalized recursively, while any other
+// values are kept byte for byte, apart from integers with MinimalIntegers. Integers keep at least the
+// MinLength of their definition and values longer than 8 bytes are not changed. As with [Nodes.SetValue],
+// values are encoded again with the configuration of the decoder of each node and the result is decoded
+// again, so Length and Raw are consistent.
+func (ns Nodes) Canonicalize(opts CanonicalOptions) (Nodes, error) {
+	res, err := canonicalize(ns, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeEdited(res)
+}
+
+func canonicalize(nodes Nodes, opts CanonicalOptions) (Nodes, error) {
+	res := make(Nodes, len(nodes))
+	for i := range nodes {
+		var err error
+		if res[i], err = nodes[i].canonicalize(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.SortByTag {
+		sort.SliceStable(res, func(i, j int) bool {
+			return res[i].Tag < res[j].Tag
+		})
+	}
+
+	return res, nil
+}
+
+func (n *Node) canonicalize(opts CanonicalOptions) (Node, error) {
+	if n.IsNested() && len(n.Value) > 0 {
+		children, err := n.decodeChildren()
+		if err != nil {
+			return Node{}, err
+		}
+
+		if children, err = canonicalize(children, opts); err != nil {
+			return Node{}, err
+		}
+
+		return n.withChildren(children)
+	}
+
+	if opts.MinimalIntegers {
+		return n.withMinimalInteger(), nil
+	}
+
+	return *n, nil
+}
+
+// withMinimalInteger returns a copy of the node with its integer value encoded with as few bytes as
+// needed, but at least MinLength, if the schema types it as an integer or date.
+func (n *Node) withMinimalInteger() Node {
+	d := n.getSafeDecoder()
+
+	definition, ok := d.schema.Lookup(n.Tag)
+	if !ok || len(n.Value) == 0 || len(n.Value) > sizes.Uint64 {
+		return *n
+	}
+
+	switch definition.Type {
+	case TypeUint, TypeDate:
+		value := n.GetPaddedUint64()
+		size := utils.MaxInt(utils.ByteCount(value), int(definition.MinLength))
+		return d.NewNode(n.Tag, d.encoder.marshalUint(value, size))
+	case TypeInt:
+		value := n.GetPaddedInt64()
+		size := utils.MaxInt(getMinimalIntSize(value), int(definition.MinLength))
+		return d.NewNode(n.Tag, d.encoder.marshalInt(value, size))
+	default:
+		return *n
+	}
+}
+
+// getMinimalIntSize returns how many bytes are needed to represent the value in two's complement.
+func getMinimalIntSize(value int64) int {
+	size := sizes.Int8
+	for size < sizes.Int64 && utils.SignExtend(uint64(value), bitsPerByte*size) != value {
+		size++
+	}
+
+	return size
+}
@@ -0,0 +1,121 @@
+package tlv
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodes_Canonicalize(t *testing.T) {
+	decoder := stdDecoder.WithSchema(testSchema)
+
+	nodes, err := decoder.DecodeBytes(data)
+	require.Nil(t, err)
+
+	other, err := decoder.NewBuilder().
+		Begin(tagMessage).
+		Begin(tagPushNotification).
+		Time(tagTimestamp, time.Unix(1625067296, 0)).
+		String(tagTitle, "Hello there!").
+		Uint64(tagActionID, 12345678).
+		End().
+		Begin(tagPushNotification).
+		Uint16(tagActionID, 240).
+		Time(tagTimestamp, time.Unix(1614571323, 0)).
+		Bool(tagSilent, true).
+		String(tagTitle, "You there?").
+		End().
+		End().
+		Nodes()
+	require.Nil(t, err)
+	require.False(t, nodes.Equal(other))
+
+	opts := CanonicalOptions{SortByTag: true, MinimalIntegers: true}
+
+	canonical, err := nodes.Canonicalize(opts)
+	require.Nil(t, err)
+
+	otherCanonical, err := other.Canonicalize(opts)
+	require.Nil(t, err)
+
+	require.True(t, canonical.Equal(otherCanonical))
+
+	encoded, err := EncodeNodes(canonical)
+	require.Nil(t, err)
+
+	otherEncoded, err := EncodeNodes(otherCanonical)
+	require.Nil(t, err)
+
+	require.Equal(t, sha256.Sum256(encoded), sha256.Sum256(otherEncoded))
+	require.Len(t, encoded, len(data))
+}
+
+func TestNodes_Canonicalize_WithoutOptions(t *testing.T) {
+	nodes := Nodes{NewNode(0x02, []byte{0x00, 0x01}), NewNode(0x01, nil)}
+
+	res, err := nodes.Canonicalize(CanonicalOptions{})
+
+	require.Nil(t, err)
+	require.True(t, nodes.Equal(res))
+	require.Equal(t, []byte{0x00, 0x02, 0x00, 0x02, 0x00, 0x01}, res[0].Raw)
+}
+
+func TestNodes_Canonicalize_WhenTheValuesAreNotNested(t *testing.T) {
+	value := []byte{0x00, 0x02, 0x00, 0x01, 0xaa, 0x00, 0x01, 0x00, 0x01, 0xbb}
+	nodes := Nodes{NewNode(0x10, value)}
+
+	res, err := nodes.Canonicalize(CanonicalOptions{SortByTag: true})
+
+	require.Nil(t, err)
+	require.Equal(t, value, res[0].Value)
+}
+
+func TestNodes_Canonicalize_WhenTheNestedValuesAreEmpty(t *testing.T) {
+	nodes, err := CreateBERDecoder().DecodeBytes([]byte{0xa5, 0x00})
+	require.Nil(t, err)
+
+	res, err := nodes.Canonicalize(CanonicalOptions{SortByTag: true})
+	require.Nil(t, err)
+	require.Equal(t, []byte{0xa5, 0x00}, res[0].Raw)
+
+	nodes = Nodes{stdDecoder.WithSchema(testSchema).NewNode(tagMessage, nil)}
+
+	res, err = nodes.Canonicalize(CanonicalOptions{SortByTag: true, MinimalIntegers: true})
+	require.Nil(t, err)
+	require.Empty(t, res[0].Value)
+}
+
+func TestNodes_Canonicalize_WithMinimalIntegers(t *testing.T) {
+	schema := MustCreateSchema(
+		TagDefinition{Tag: 0x01, Type: TypeInt},
+		TagDefinition{Tag: 0x02, Type: TypeUint, MinLength: 2},
+		TagDefinition{Tag: 0x03, Type: TypeString},
+	)
+	decoder := MustCreateDecoder(1, 1, binary.LittleEndian).WithSchema(schema)
+
+	nodes := Nodes{
+		decoder.NewNode(0x01, []byte{0xfe, 0xff, 0xff, 0xff}),
+		decoder.NewNode(0x02, []byte{0x01, 0x00, 0x00, 0x00}),
+		decoder.NewNode(0x03, []byte{0x00, 0x00}),
+		decoder.NewNode(0x04, []byte{0x00, 0x00}),
+	}
+
+	res, err := nodes.Canonicalize(CanonicalOptions{MinimalIntegers: true})
+	require.Nil(t, err)
+
+	require.Equal(t, []byte{0xfe}, res[0].Value)
+	require.Equal(t, []byte{0x01, 0x00}, res[1].Value)
+	require.Equal(t, []byte{0x00, 0x00}, res[2].Value)
+	require.Equal(t, []byte{0x00, 0x00}, res[3].Value)
+}
+
+func TestGetMinimalIntSize(t *testing.T) {
+	scenarios := map[int64]int{0: 1, -1: 1, 127: 1, 128: 2, -128: 1, -129: 2, 1 << 40: 6, -1 << 63: 8}
+
+	for value, expected := range scenarios {
+		require.Equal(t, expected, getMinimalIntSize(value), value)
+	}
+}
@@ -0,0 +1,92 @@
+package tlv
+
+// WithCopiedValues makes decoded nodes own their bytes instead of referencing the input, so the input
+// buffer can be reused while the nodes are in use. The bytes of each decoding are copied to a single
+// buffer, instead of one per node. Nodes decoded from readers already own their bytes.
+func WithCopiedValues() Option {
+	return func(c *decoderConfig) {
+		c.copyValues = true
+	}
+}
+
+// Clone returns a copy of the node that does not reference the original Raw and Value buffers.
+func (n *Node) Clone() Node {
+	res := *n
+	res.detachInto(make([]byte, 0, n.getDetachedSize()))
+
+	return res
+}
+
+// Clone returns copies of the nodes that do not reference the original buffers.
+// The bytes of all nodes are copied to a single buffer.
+func (ns Nodes) Clone() Nodes {
+	if ns == nil {
+		return nil
+	}
+
+	res := append(make(Nodes, 0, len(ns)), ns...)
+	res.detach()
+
+	return res
+}
+
+// ownNodes detaches the nodes from the input buffer when the decoder was created with WithCopiedValues.
+func (d *decoder) ownNodes(nodes Nodes) {
+	if d.copyValues {
+		nodes.detach()
+	}
+}
+
+// detach copies the bytes of the nodes to a single buffer and makes the nodes reference it.
+func (ns Nodes) detach() {
+	size := 0
+	for i := range ns {
+		size += ns[i].getDetachedSize()
+	}
+
+	arena := make([]byte, 0, size)
+	for i := range ns {
+		arena = ns[i].detachInto(arena)
+	}
+}
+
+// detachInto appends the node bytes to the arena and makes the node reference them.
+// Each slice is capped, so appending to it never overwrites the bytes of other nodes.
+func (n *Node) detachInto(arena []byte) []byte {
+	valueInRaw := n.isValueInRaw()
+
+	if n.Raw != nil {
+		start := len(arena)
+		arena = append(arena, n.Raw...)
+		n.Raw = arena[start:len(arena):len(arena)]
+	}
+
+	if valueInRaw {
+		n.Value = n.Raw[len(n.Raw)-len(n.Value):]
+	} else if n.Value != nil {
+		start := len(arena)
+		arena = append(arena, n.Value...)
+		n.Value = arena[start:len(arena):len(arena)]
+	}
+
+	n.index = n.getSafeDecoder().newChildIndex(n.Tag, n.Value)
+
+	return arena
+}
+
+func (n *Node) getDetachedSize() int {
+	if n.isValueInRaw() {
+		return len(n.Raw)
+	}
+
+	return len(n.Raw) + len(n.Value)
+}
+
+// isValueInRaw returns whether the value is the end of Raw, as in decoded nodes.
+func (n *Node) isValueInRaw() bool {
+	if n.Raw == nil || n.Value == nil || len(n.Value) > len(n.Raw) {
+		return false
+	}
+
+	return len(n.Value) == 0 || &n.Raw[len(n.Raw)-len(n.Value)] == &n.Value[0]
+}
@@ -0,0 +1,101 @@
+package tlv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode_Clone(t *testing.T) {
+	buffer := append([]byte{}, data...)
+	nodes, err := DecodeBytes(buffer)
+	require.Nil(t, err)
+
+	clone := nodes[0].Clone()
+	copy(buffer, make([]byte, len(buffer)))
+
+	require.Equal(t, data, clone.Raw)
+	require.Equal(t, data[4:], clone.Value)
+	require.Equal(t, uint64(4), clone.GetValueOffset())
+
+	children, err := clone.GetNodes()
+	require.Nil(t, err)
+	require.Len(t, children, 2)
+}
+
+func TestNode_Clone_WhenCreatedManually(t *testing.T) {
+	value := []byte("title")
+	node := NewNode(tagTitle, value)
+
+	clone := node.Clone()
+	value[0] = 'T'
+
+	require.Nil(t, clone.Raw)
+	require.Equal(t, []byte("title"), clone.Value)
+}
+
+func TestNodes_Clone(t *testing.T) {
+	buffer := append([]byte{}, data...)
+	nodes, err := DecodeBytes(buffer)
+	require.Nil(t, err)
+
+	children, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	clones := children.Clone()
+	copy(buffer, make([]byte, len(buffer)))
+
+	require.Equal(t, data[4:39], clones[0].Raw)
+	require.Equal(t, data[39:], clones[1].Raw)
+	require.Equal(t, uint64(39), clones[1].GetOffset())
+
+	clones[0].Value = append(clones[0].Value, 0xff)
+	require.Equal(t, data[39:], clones[1].Raw)
+}
+
+func TestNodes_Clone_WhenNil(t *testing.T) {
+	require.Nil(t, Nodes(nil).Clone())
+}
+
+func TestDecoder_WithCopiedValues(t *testing.T) {
+	decoder := MustCreateDecoderWithOptions(WithCopiedValues())
+	buffer := append([]byte{}, data...)
+
+	nodes, err := decoder.DecodeBytes(buffer)
+	require.Nil(t, err)
+
+	into, err := decoder.DecodeBytesInto(nil, buffer)
+	require.Nil(t, err)
+
+	single, _, err := decoder.DecodeSingle(buffer)
+	require.Nil(t, err)
+
+	partial, err := decoder.DecodePartial(buffer, RecoveryStop)
+	require.Nil(t, err)
+
+	var iterated Nodes
+	decoder.All(buffer)(func(node Node, err error) bool {
+		require.Nil(t, err)
+		iterated = append(iterated, node)
+		return true
+	})
+
+	copy(buffer, make([]byte, len(buffer)))
+
+	for _, node := range []Node{nodes[0], into[0], single, partial[0], iterated[0]} {
+		require.Equal(t, data, node.Raw)
+		require.Equal(t, data[4:], node.Value)
+	}
+}
+
+func TestDecoder_WithCopiedValues_AllocatesOnce(t *testing.T) {
+	decoder := MustCreateDecoderWithOptions(WithCopiedValues())
+	input := newTelemetryData(100)
+	dst := make(Nodes, 0, 100)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = decoder.DecodeBytesInto(dst, input)
+	})
+
+	require.Equal(t, float64(1), allocs)
+}
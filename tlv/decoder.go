@@ -3,8 +3,8 @@ package tlv
 import (
 	"encoding/binary"
 	"io"
+	"time"
 
-	"github.com/pauloavelar/go-tlv/tlv/internal/errors"
 	"github.com/pauloavelar/go-tlv/tlv/internal/utils"
 )
 
@@ -14,21 +14,75 @@ type Decoder interface {
 	DecodeReader(reader io.Reader) (Nodes, error)
 	// DecodeBytes decodes a byte array to a list of TLV [Nodes].
 	DecodeBytes(data []byte) (Nodes, error)
+	// NewScanner creates a [Scanner] that decodes TLV [Nodes] from a reader one at a time.
+	NewScanner(reader io.Reader) *Scanner
+	// All returns an iterator that decodes the nodes in a byte array one at a time.
+	All(data []byte) func(yield func(Node, error) bool)
+	// DecodeIndexed decodes a byte array to TLV [Nodes] wrapped in an [IndexedNodes] view.
+	DecodeIndexed(data []byte) (*IndexedNodes, error)
+	// DecodeBytesInto decodes a byte array to TLV [Nodes] appended to dst.
+	DecodeBytesInto(dst Nodes, data []byte) (Nodes, error)
+	// DecodePartial decodes a byte array to as many TLV [Nodes] as possible, reporting corrupted ones.
+	DecodePartial(data []byte, recovery Recovery) (Nodes, error)
 	// DecodeSingle decodes a byte array to a single TLV [Node].
 	DecodeSingle(data []byte) (res Node, read uint64, err error)
+	// Unmarshal decodes a byte array into a struct annotated with `tlv` struct tags.
+	Unmarshal(data []byte, v interface{}) error
 	// NewNode creates a new node using the decoder configuration.
 	NewNode(tag Tag, value []byte) Node
+	// NewBoolNode creates a new node with a boolean value.
+	NewBoolNode(tag Tag, value bool) Node
+	// NewUint8Node creates a new node with an uint8 value.
+	NewUint8Node(tag Tag, value uint8) Node
+	// NewUint16Node creates a new node with an uint16 value in the decoder byte order.
+	NewUint16Node(tag Tag, value uint16, options ...ValueOption) Node
+	// NewUint32Node creates a new node with an uint32 value in the decoder byte order.
+	NewUint32Node(tag Tag, value uint32, options ...ValueOption) Node
+	// NewUint64Node creates a new node with an uint64 value in the decoder byte order.
+	NewUint64Node(tag Tag, value uint64, options ...ValueOption) Node
+	// NewStringNode creates a new node with a UTF-8 text value.
+	NewStringNode(tag Tag, value string) Node
+	// NewDateNode creates a new node with a date value as Unix seconds.
+	NewDateNode(tag Tag, value time.Time, options ...ValueOption) Node
+	// NewNestedNode creates a new node whose value holds the encoded children.
+	NewNestedNode(tag Tag, children ...Node) (Node, error)
+	// NewBuilder creates a builder for TLV messages with the decoder configuration.
+	NewBuilder() *Builder
 	// GetByteOrder returns the decoder endianness configuration.
 	GetByteOrder() binary.ByteOrder
+	// GetEncoder returns an encoder with the same configuration as the decoder.
+	GetEncoder() Encoder
+	// WithSchema returns a copy of the decoder that validates and names nodes with the schema.
+	WithSchema(schema *Schema) Decoder
+	// GetSchema returns the decoder schema, if any.
+	GetSchema() *Schema
+	// WithLimits returns a copy of the decoder that enforces the limits when decoding.
+	WithLimits(limits Limits) Decoder
+	// GetLimits returns the decoder limits.
+	GetLimits() Limits
+	// FromTree encodes trees with the decoder configuration and decodes them back as TLV [Nodes].
+	FromTree(trees ...TreeNode) (Nodes, error)
+	// DecodeJSON parses a JSON list of trees and rebuilds it as TLV [Nodes].
+	DecodeJSON(data []byte) (Nodes, error)
 }
 
 type decoder struct {
-	tagSize     uint8
-	lengthSize  uint8
-	minNodeSize uint8
-	byteOrder   binary.ByteOrder
+	header       headerFormat
+	byteOrder    binary.ByteOrder
+	littleEndian bool
+	encoder      *encoder
+	schema       *Schema
+	limits       Limits
+	strict       strictMode
+	tagIndex     bool
+	copyValues   bool
 }
 
+const (
+	fieldTag    = "tag"
+	fieldLength = "length"
+)
+
 const (
 	minTagSize = 1 // 2^1 = 2
 	maxTagSize = 8 // 2^8 = 256
@@ -49,79 +103,266 @@ func MustCreateDecoder(tagSize, lengthSize uint8, byteOrder binary.ByteOrder) De
 // CreateDecoder creates a [Decoder] using custom configuration.
 // Hint: tagSize and lengthSize must be numbers between 1 and 8.
 func CreateDecoder(tagSize, lengthSize uint8, byteOrder binary.ByteOrder) (Decoder, error) {
-	if tagSize < minTagSize || tagSize > maxTagSize {
-		return nil, errors.NewInvalidSizeError("tag", tagSize, minTagSize, maxTagSize)
+	if err := validateSizes(tagSize, lengthSize); err != nil {
+		return nil, err
 	}
 
-	if lengthSize < minLenSize || lengthSize > maxLenSize {
-		return nil, errors.NewInvalidSizeError("length", lengthSize, minLenSize, maxLenSize)
+	return newFixedDecoder(tagSize, lengthSize, byteOrder), nil
+}
+
+func newFixedDecoder(tagSize, lengthSize uint8, byteOrder binary.ByteOrder) *decoder {
+	header := &fixedHeader{tagSize: tagSize, lengthSize: lengthSize, littleEndian: utils.IsLittleEndian(byteOrder)}
+
+	return newDecoder(header, byteOrder)
+}
+
+func newDecoder(header headerFormat, byteOrder binary.ByteOrder) *decoder {
+	return &decoder{
+		header:       header,
+		byteOrder:    byteOrder,
+		littleEndian: utils.IsLittleEndian(byteOrder),
+		encoder:      newEncoder(header, byteOrder),
+	}
+}
+
+func validateSizes(tagSize, lengthSize uint8) error {
+	if tagSize < minTagSize || tagSize > maxTagSize {
+		return &InvalidSizeError{Field: fieldTag, Size: tagSize, Min: minTagSize, Max: maxTagSize}
 	}
 
-	res := &decoder{
-		tagSize:     tagSize,
-		lengthSize:  lengthSize,
-		minNodeSize: tagSize + lengthSize,
-		byteOrder:   byteOrder,
+	if lengthSize < minLenSize || lengthSize > maxLenSize {
+		return &InvalidSizeError{Field: fieldLength, Size: lengthSize, Min: minLenSize, Max: maxLenSize}
 	}
 
-	return res, nil
+	return nil
 }
 
 // DecodeReader decodes the full contents of a [io.Reader] as TLV [Nodes].
-// Note: the current implementation loads the entire Reader data into memory.
+// Note: the entire Reader data is loaded into memory, use NewScanner to decode it node by node.
 func (d *decoder) DecodeReader(reader io.Reader) (Nodes, error) {
-	data, err := io.ReadAll(reader)
+	data, err := d.readAll(reader)
 	if err != nil {
 		return nil, err
 	}
 
-	return d.DecodeBytes(data)
+	return d.decodeBytes(data, 0, nil, nil)
 }
 
 // DecodeBytes decodes a byte array as TLV [Nodes].
 func (d *decoder) DecodeBytes(data []byte) (Nodes, error) {
-	node, read, err := d.DecodeSingle(data)
+	res, err := d.decodeBytes(data, 0, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if uint64(len(data)) == read {
-		return Nodes{node}, nil
-	}
+	d.ownNodes(res)
+	return res, nil
+}
 
-	next, err := d.DecodeBytes(data[read:])
+// DecodeBytesInto decodes a byte array as TLV [Nodes] appended to dst, so its capacity can be reused
+// across messages. On errors, dst is returned without the nodes of the failing message.
+// Note: the nodes reference the data buffer, which must not be changed while they are in use,
+// unless the decoder was created with [WithCopiedValues].
+func (d *decoder) DecodeBytesInto(dst Nodes, data []byte) (Nodes, error) {
+	res, err := d.decodeBytesInto(dst, data, 0, nil, nil)
 	if err != nil {
-		return nil, err
+		return res, err
 	}
 
-	return append(Nodes{node}, next...), nil
+	d.ownNodes(res[len(dst):])
+	return res, nil
 }
 
 // DecodeSingle decodes a byte array as a single TLV [Node].
 func (d *decoder) DecodeSingle(data []byte) (res Node, read uint64, err error) {
-	if len(data) < int(d.minNodeSize) {
-		return res, 0, errors.NewMessageTooShortError(data)
+	if res, read, err = d.decodeSingle(data, 0, nil); err != nil {
+		return res, read, err
+	}
+
+	res.count = d.newNodeCount()
+	if err = d.countNode(res.count, 0, nil); err != nil {
+		return Node{}, 0, err
+	}
+
+	if err = d.checkSingle(&res, read, len(data)); err != nil {
+		return Node{}, 0, err
+	}
+
+	if d.copyValues {
+		res = res.Clone()
+	}
+
+	return res, read, nil
+}
+
+// decodeBytes decodes nodes located at an offset of the original buffer, under the parent path,
+// validating them against the schema if there is one. The nodes are added to the count shared with
+// the root decoding they come from, or to a new one if it is nil.
+func (d *decoder) decodeBytes(data []byte, offset uint64, path []Tag, count *nodeCount) (Nodes, error) {
+	return d.decodeBytesInto(nil, data, offset, path, count)
+}
+
+// decodeBytesInto works as decodeBytes but appends the nodes to dst, returning dst unchanged on errors.
+func (d *decoder) decodeBytesInto(dst Nodes, data []byte, offset uint64, path []Tag, count *nodeCount) (Nodes, error) {
+	res, err := d.decodeSiblings(dst, data, offset, path, count)
+	if err != nil {
+		return dst, err
+	}
+
+	if err = d.validate(res[len(dst):], path); err != nil {
+		count.release(len(res) - len(dst))
+		return dst, err
+	}
+
+	return res, nil
+}
+
+// validate checks the nodes against the schema, if there is one, as children of the last tag in the path.
+// Their nested values are validated when they are decoded.
+func (d *decoder) validate(nodes Nodes, path []Tag) error {
+	if d.schema == nil {
+		return nil
+	}
+
+	var parent *TagDefinition
+	if len(path) > 0 {
+		if definition, ok := d.schema.Lookup(path[len(path)-1]); ok {
+			parent = &definition
+		}
+	}
+
+	return d.schema.validate(nodes, parent, false)
+}
+
+// isNested returns whether values with the tag hold nested TLV according to the schema or the tag itself.
+func (d *decoder) isNested(tag Tag) bool {
+	return d.schema.IsNested(tag) || d.header.isConstructed(tag)
+}
+
+// decodeSiblings appends all nodes in data to dst, growing it only once for the nodes found by a header pre-scan.
+func (d *decoder) decodeSiblings(dst Nodes, data []byte, offset uint64, path []Tag, count *nodeCount) (Nodes, error) {
+	if err := d.checkDepth(offset, path); err != nil {
+		return nil, err
+	}
+
+	if count == nil {
+		count = d.newNodeCount()
+	}
+
+	start := len(dst)
+	dst = growNodes(dst, d.countNodes(data))
+	checker := d.newStrictChecker()
+
+	for {
+		node, read, err := d.decodeSingle(data, offset, path)
+		if err == nil {
+			err = checker.check(&node)
+		}
+
+		if err == nil {
+			err = d.countNode(count, offset, path)
+		}
+
+		if err != nil {
+			count.release(len(dst) - start)
+			return nil, checker.checkTrailing(err, len(dst)-start, uint64(len(data)))
+		}
+
+		node.count = count
+		dst = append(dst, node)
+		if read == uint64(len(data)) {
+			return dst, nil
+		}
+
+		data, offset = data[read:], offset+read
+	}
+}
+
+// countNodes returns how many consecutive nodes at the start of data have valid headers and lengths,
+// up to MaxNodes.
+func (d *decoder) countNodes(data []byte) int {
+	count := 0
+
+	for len(data) > 0 && (d.limits.MaxNodes <= 0 || count < d.limits.MaxNodes) {
+		_, length, size, err := d.header.read(data)
+		if err != nil || length > uint64(len(data)-size) {
+			break
+		}
+
+		data = data[uint64(size)+length:]
+		count++
 	}
 
-	tag := utils.GetPaddedUint64(d.byteOrder, data[:d.tagSize])
-	length := utils.GetPaddedUint64(d.byteOrder, data[d.tagSize:d.minNodeSize])
-	messageLength := uint64(d.minNodeSize) + length
+	return count
+}
 
-	if len(data) < int(messageLength) {
-		return res, 0, errors.NewLengthMismatchError(length, data, d.minNodeSize)
+// growNodes makes sure dst has capacity for n more nodes.
+func growNodes(dst Nodes, n int) Nodes {
+	if cap(dst)-len(dst) >= n {
+		return dst
 	}
 
+	res := make(Nodes, len(dst), len(dst)+n)
+	copy(res, dst)
+
+	return res
+}
+
+func (d *decoder) decodeSingle(data []byte, offset uint64, path []Tag) (res Node, read uint64, err error) {
+	tag, length, headerSize, err := d.header.read(data)
+	if err != nil {
+		return res, 0, d.newHeaderError(err, uint64(len(data)), offset, path)
+	}
+
+	if err = d.checkValueLength(tag, length, offset, path); err != nil {
+		return res, 0, err
+	}
+
+	available := uint64(len(data) - headerSize)
+	if length > available {
+		return res, 0, &LengthMismatchError{
+			Offset:    offset,
+			Tag:       tag,
+			Length:    Length(length),
+			Available: available,
+			Path:      copyPath(path),
+			schema:    d.schema,
+		}
+	}
+
+	messageLength := uint64(headerSize) + length
+
 	node := Node{
-		Tag:     Tag(tag),
+		Tag:     tag,
 		Length:  Length(length),
-		Value:   data[d.minNodeSize:messageLength],
+		Value:   data[headerSize:messageLength],
 		Raw:     data[:messageLength],
 		decoder: d,
+		offset:  offset,
+		path:    path,
+		index:   d.newChildIndex(tag, data[headerSize:messageLength]),
 	}
 
 	return node, messageLength, nil
 }
 
+// newHeaderError converts header format errors into errors carrying the node location.
+func (d *decoder) newHeaderError(err error, available, offset uint64, path []Tag) error {
+	if err == errIncompleteHeader {
+		return &MessageTooShortError{Offset: offset, Available: available, Path: copyPath(path), schema: d.schema}
+	}
+
+	return &MalformedHeaderError{Offset: offset, Reason: err.Error(), Path: copyPath(path), schema: d.schema}
+}
+
+func copyPath(path []Tag) []Tag {
+	if len(path) == 0 {
+		return nil
+	}
+
+	return append([]Tag{}, path...)
+}
+
 // NewNode creates a new [Node] using the [Decoder] configuration.
 func (d *decoder) NewNode(tag Tag, value []byte) Node {
 	return Node{
@@ -129,6 +370,7 @@ func (d *decoder) NewNode(tag Tag, value []byte) Node {
 		Length:  Length(len(value)),
 		Value:   value,
 		decoder: d,
+		index:   d.newChildIndex(tag, value),
 	}
 }
 
@@ -136,3 +378,23 @@ func (d *decoder) NewNode(tag Tag, value []byte) Node {
 func (d *decoder) GetByteOrder() binary.ByteOrder {
 	return d.byteOrder
 }
+
+// GetEncoder returns an [Encoder] with the same configuration as the [Decoder].
+func (d *decoder) GetEncoder() Encoder {
+	return d.encoder
+}
+
+// WithSchema returns a copy of the [Decoder] that validates decoded nodes against the [Schema]
+// and uses its names in errors and String output. Each level is validated when it is decoded, so
+// nested values are validated by GetNodes, while [Schema.Validate] checks whole trees at once.
+func (d *decoder) WithSchema(schema *Schema) Decoder {
+	res := *d
+	res.schema = schema
+
+	return &res
+}
+
+// GetSchema returns the [Decoder] schema, if any.
+func (d *decoder) GetSchema() *Schema {
+	return d.schema
+}
@@ -2,6 +2,7 @@ package tlv
 
 import (
 	"encoding/binary"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -46,3 +47,103 @@ func TestCreateDecoder_WhenTheLengthSizeIsTooBig(t *testing.T) {
 	require.NotNil(t, err)
 	require.Contains(t, err.Error(), "length size")
 }
+
+func TestDecoder_DecodeBytesInto(t *testing.T) {
+	first, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	dst := make(Nodes, 0, 8)
+	dst, err = DecodeBytesInto(dst, data)
+	require.Nil(t, err)
+
+	res, err := DecodeBytesInto(dst, newTelemetryData(3))
+
+	require.Nil(t, err)
+	require.Len(t, res, 4)
+	require.Equal(t, first[0], res[0])
+	require.Equal(t, cap(dst), cap(res))
+}
+
+func TestDecoder_DecodeBytesInto_WhenTheDataIsCorrupted(t *testing.T) {
+	dst := Nodes{NewNode(0x01, nil)}
+
+	res, err := DecodeBytesInto(dst, data[:len(data)-1])
+
+	require.True(t, errors.Is(err, ErrLengthMismatch))
+	require.Equal(t, dst, res)
+}
+
+func TestDecoder_DecodeBytes_WithManySiblings(t *testing.T) {
+	nodes, err := DecodeBytes(newTelemetryData(500000))
+
+	require.Nil(t, err)
+	require.Len(t, nodes, 500000)
+	require.Equal(t, len(nodes), cap(nodes))
+	require.Equal(t, uint64(6*499999), nodes[499999].offset)
+}
+
+// newTelemetryData creates a message with count small sibling nodes.
+func newTelemetryData(count int) []byte {
+	res := make([]byte, 0, count*6)
+	for i := 0; i < count; i++ {
+		res = append(res, 0x00, byte(i), 0x00, 0x02, byte(i>>8), byte(i))
+	}
+
+	return res
+}
+
+func BenchmarkDecodeBytes_100Nodes(b *testing.B) {
+	benchmarkDecodeBytes(b, 100)
+}
+
+func BenchmarkDecodeBytes_10000Nodes(b *testing.B) {
+	benchmarkDecodeBytes(b, 10000)
+}
+
+func BenchmarkDecodeBytesInto_10000Nodes(b *testing.B) {
+	input := newTelemetryData(10000)
+	dst := make(Nodes, 0, 10000)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var err error
+		if dst, err = DecodeBytesInto(dst[:0], input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkDecodeBytes(b *testing.B, count int) {
+	input := newTelemetryData(count)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeBytes(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDecoder_DecodeSingle_DoesNotAllocate(t *testing.T) {
+	input := make([]byte, 20)
+
+	for size := uint8(1); size <= 8; size++ {
+		for _, byteOrder := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			decoder := MustCreateDecoder(size, size, byteOrder)
+
+			allocs := testing.AllocsPerRun(100, func() {
+				if _, _, err := decoder.DecodeSingle(input); err != nil {
+					t.Fatal(err)
+				}
+			})
+
+			require.Zero(t, allocs)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package tlv
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ChangeKind describes how a node differs between the trees compared by [Diff].
+type ChangeKind uint8
+
+// Kinds of changes reported by [Diff].
+const (
+	ChangeAdded    ChangeKind = iota + 1 // The node is only in the new tree.
+	ChangeRemoved                        // The node is only in the old tree.
+	ChangeModified                       // The node is in both trees with different values.
+)
+
+// Change is a difference between the trees compared by [Diff].
+type Change struct {
+	Kind ChangeKind
+	Path Path  // Location of the node, each segment selecting a tag and its position among siblings with that tag.
+	Old  *Node // Node in the old tree, nil for added nodes.
+	New  *Node // Node in the new tree, nil for removed nodes.
+}
+
+// Changes lists the differences found by [Diff].
+type Changes []Change
+
+// diffGroup holds the positions of the siblings with a tag in both trees.
+type diffGroup struct {
+	tag           Tag
+	before, after []int
+}
+
+// Diff compares the old tree a with the new tree b and returns the nodes added, removed and modified.
+// Siblings are paired by tag and by position among the siblings with the same tag, so repeated tags
+// are compared in order and moving nodes with different tags around is not a change. Modified nodes
+// whose values decode as TLV in both trees, unless the schema defines them as not nested, are compared
+// recursively, reporting only the changes of their nested nodes, while any other values are opaque.
+// Changes follow the order of the trees, grouped by tag, and their paths can be used with [Nodes.Find]
+// and [Nodes.SetValue].
+func Diff(a, b Nodes) Changes {
+	return diffSiblings(nil, nil, a, b)
+}
+
+func diffSiblings(dst Changes, path Path, a, b Nodes) Changes {
+	for _, group := range groupByTag(a, b) {
+		for i := 0; i < len(group.before) || i < len(group.after); i++ {
+			nodePath := append(path[:len(path):len(path)], MatchIndex(group.tag, i))
+
+			switch {
+			case i >= len(group.after):
+				dst = append(dst, Change{Kind: ChangeRemoved, Path: nodePath, Old: copyNode(a[group.before[i]])})
+			case i >= len(group.before):
+				dst = append(dst, Change{Kind: ChangeAdded, Path: nodePath, New: copyNode(b[group.after[i]])})
+			default:
+				dst = diffNodes(dst, nodePath, copyNode(a[group.before[i]]), copyNode(b[group.after[i]]))
+			}
+		}
+	}
+
+	return dst
+}
+
+func diffNodes(dst Changes, path Path, a, b *Node) Changes {
+	if bytes.Equal(a.Value, b.Value) {
+		return dst
+	}
+
+	oldChildren, oldNested, _ := a.decodeNested()
+	newChildren, newNested, _ := b.decodeNested()
+
+	if oldNested && newNested {
+		return diffSiblings(dst, path, oldChildren, newChildren)
+	}
+
+	return append(dst, Change{Kind: ChangeModified, Path: path, Old: a, New: b})
+}
+
+// groupByTag returns the positions of the siblings of both trees, grouped by tag in order of appearance.
+func groupByTag(a, b Nodes) []diffGroup {
+	var res []diffGroup
+	groups := make(map[Tag]int)
+
+	getGroup := func(tag Tag) *diffGroup {
+		i, ok := groups[tag]
+		if !ok {
+			i = len(res)
+			groups[tag] = i
+			res = append(res, diffGroup{tag: tag})
+		}
+
+		return &res[i]
+	}
+
+	for i := range a {
+		group := getGroup(a[i].Tag)
+		group.before = append(group.before, i)
+	}
+
+	for i := range b {
+		group := getGroup(b[i].Tag)
+		group.after = append(group.after, i)
+	}
+
+	return res
+}
+
+func copyNode(node Node) *Node {
+	return &node
+}
+
+// String returns "added", "removed" or "modified".
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", uint8(k))
+	}
+}
+
+func (k ChangeKind) symbol() string {
+	switch k {
+	case ChangeAdded:
+		return "+"
+	case ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// String renders the changes with [DefaultDumpOptions], see [Changes.Dump].
+func (cs Changes) String() string {
+	var buf bytes.Buffer
+	_ = cs.Dump(&buf, DefaultDumpOptions)
+
+	return buf.String()
+}
+
+// Dump writes the changes as text, one line per change starting with "+" for added nodes, "-" for
+// removed nodes and "~" for modified ones, followed by the path (with tag names when there is a schema)
+// and the length and value preview of the nodes, old before new. MaxDepth is ignored.
+func (cs Changes) Dump(w io.Writer, opts DumpOptions) error {
+	d := dumper{opts: opts}
+	for i := range cs {
+		d.writeChange(&cs[i])
+	}
+
+	_, err := w.Write(d.buf.Bytes())
+	return err
+}
+
+func (d *dumper) writeChange(change *Change) {
+	node := change.New
+	if node == nil {
+		node = change.Old
+	}
+
+	d.buf.WriteString(change.Kind.symbol() + " ")
+	d.writeChangePath(change.Path, node.getSafeDecoder().schema)
+
+	separator := ","
+	if change.Old != nil {
+		fmt.Fprintf(&d.buf, "%s %d bytes", separator, len(change.Old.Value))
+		d.writeValue(change.Old.Value)
+		separator = " ->"
+	}
+
+	if change.New != nil {
+		fmt.Fprintf(&d.buf, "%s %d bytes", separator, len(change.New.Value))
+		d.writeValue(change.New.Value)
+	}
+
+	d.buf.WriteByte('\n')
+}
+
+func (d *dumper) writeChangePath(path Path, schema *Schema) {
+	for i := range path {
+		if i > 0 {
+			d.buf.WriteString(pathSeparator)
+		}
+
+		fmt.Fprintf(&d.buf, "%s%s%d%s", schema.formatPathTag(path[i].Tag), pathIndexPrefix, path[i].Index, pathIndexSuffix)
+	}
+}
@@ -0,0 +1,142 @@
+package tlv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_WhenTheTreesAreEqual(t *testing.T) {
+	a, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	b, err := DecodeBytes(append([]byte{}, data...))
+	require.Nil(t, err)
+
+	changes := Diff(a, b)
+
+	require.Empty(t, changes)
+	require.Equal(t, "", changes.String())
+}
+
+func TestDiff(t *testing.T) {
+	a, err := stdDecoder.WithSchema(testSchema).DecodeBytes(data)
+	require.Nil(t, err)
+
+	b, err := a.SetValue(Path{MatchTag(tagMessage), MatchIndex(tagPushNotification, 1), MatchTag(tagTitle)}, []byte("Hey"))
+	require.Nil(t, err)
+
+	b, err = b.Remove(NewPath(tagMessage, tagPushNotification, tagSilent))
+	require.Nil(t, err)
+
+	b, err = b.Append(Path{MatchTag(tagMessage), MatchIndex(tagPushNotification, 0)}, NewStringNode(tagTitle, "Again"))
+	require.Nil(t, err)
+
+	changes := Diff(a, b)
+
+	require.Equal(t, Changes{
+		{
+			Kind: ChangeAdded,
+			Path: Path{MatchIndex(tagMessage, 0), MatchIndex(tagPushNotification, 0), MatchIndex(tagTitle, 1)},
+			New:  changes[0].New,
+		},
+		{
+			Kind: ChangeModified,
+			Path: Path{MatchIndex(tagMessage, 0), MatchIndex(tagPushNotification, 1), MatchIndex(tagTitle, 0)},
+			Old:  changes[1].Old,
+			New:  changes[1].New,
+		},
+		{
+			Kind: ChangeRemoved,
+			Path: Path{MatchIndex(tagMessage, 0), MatchIndex(tagPushNotification, 1), MatchIndex(tagSilent, 0)},
+			Old:  changes[2].Old,
+		},
+	}, changes)
+
+	require.Equal(t, []byte("Again"), changes[0].New.Value)
+	require.Equal(t, []byte("You there?"), changes[1].Old.Value)
+	require.Equal(t, []byte("Hey"), changes[1].New.Value)
+	require.Equal(t, []byte{0x01}, changes[2].Old.Value)
+
+	old, err := a.Find(changes[2].Path)
+	require.Nil(t, err)
+	require.Equal(t, *changes[2].Old, old[0])
+}
+
+func TestDiff_WhenRepeatedTagsAreRemoved(t *testing.T) {
+	a, err := stdDecoder.WithSchema(testSchema).DecodeBytes(data)
+	require.Nil(t, err)
+
+	b, err := a.Remove(Path{MatchTag(tagMessage), MatchIndex(tagPushNotification, 0)})
+	require.Nil(t, err)
+
+	changes := Diff(a, b)
+
+	require.Equal(t, ChangeRemoved, changes[len(changes)-1].Kind)
+	require.Equal(t, "0x1[0]/0x101[1]", changes[len(changes)-1].Path.String())
+
+	for _, change := range changes[:len(changes)-1] {
+		require.Equal(t, MatchIndex(tagPushNotification, 0), change.Path[1])
+	}
+}
+
+func TestDiff_WithoutSchema(t *testing.T) {
+	a, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	b, err := a.SetValue(Path{MatchTag(tagMessage), MatchIndex(tagPushNotification, 1), MatchTag(tagTitle)}, []byte("Hey"))
+	require.Nil(t, err)
+
+	changes := Diff(a, b)
+
+	require.Len(t, changes, 1)
+	require.Equal(t, ChangeModified, changes[0].Kind)
+	require.Equal(t, "0x1[0]/0x101[1]/0x102[0]", changes[0].Path.String())
+}
+
+func TestDiff_WhenTheValuesAreNotNested(t *testing.T) {
+	decoder := stdDecoder.WithSchema(MustCreateSchema(TagDefinition{Tag: 0x10, Name: "payload", Type: TypeBytes}))
+
+	a := Nodes{decoder.NewNode(0x10, []byte{0x00, 0x01, 0x00, 0x01, 'a'})}
+	b := Nodes{decoder.NewNode(0x10, []byte{0x00, 0x01, 0x00, 0x01, 'b'})}
+
+	changes := Diff(a, b)
+
+	require.Len(t, changes, 1)
+	require.Equal(t, ChangeModified, changes[0].Kind)
+	require.Equal(t, "0x10[0]", changes[0].Path.String())
+	require.NotContains(t, changes.String(), "0x10[0]/0x1[0]")
+}
+
+func TestChanges_Dump(t *testing.T) {
+	decoder := stdDecoder.WithSchema(testSchema)
+
+	a, err := decoder.DecodeBytes(data)
+	require.Nil(t, err)
+
+	b, err := a.SetValue(Path{MatchTag(tagMessage), MatchIndex(tagPushNotification, 1), MatchTag(tagTitle)}, []byte("Hey"))
+	require.Nil(t, err)
+
+	b, err = b.Remove(NewPath(tagMessage, tagPushNotification, tagSilent))
+	require.Nil(t, err)
+
+	b, err = b.Append(nil, decoder.NewUint8Node(0x02, 1))
+	require.Nil(t, err)
+
+	var buf bytes.Buffer
+	require.Nil(t, Diff(a, b).Dump(&buf, DumpOptions{MaxValueBytes: 4}))
+
+	expected := "~ message[0]/push_notification[1]/title[0], " +
+		"10 bytes: 59 6f 75 20 |You | (+6 bytes) -> 3 bytes: 48 65 79 |Hey|\n" +
+		"- message[0]/push_notification[1]/silent[0], 1 bytes: 01 |.|\n" +
+		"+ 0x2[0], 1 bytes: 01 |.|\n"
+	require.Equal(t, expected, buf.String())
+}
+
+func TestChangeKind_String(t *testing.T) {
+	require.Equal(t, "added", ChangeAdded.String())
+	require.Equal(t, "removed", ChangeRemoved.String())
+	require.Equal(t, "modified", ChangeModified.String())
+	require.Equal(t, "ChangeKind(9)", ChangeKind(9).String())
+}
@@ -19,6 +19,11 @@ as well as nested TLV [Nodes].
 	node.GetNodes()
 	node.GetPaddedUint8()
 
+[Nodes] can be encoded back to bytes with an [Encoder], which is available for
+every [Decoder] through GetEncoder, so messages round-trip byte for byte:
+
+	data, err := decoder.GetEncoder().EncodeNodes(nodes)
+
 Note: [Nodes] decoded with a custom configuration retain the configuration
 when parsing their values as other nodes, so messages always have consistent
 tag/length sizes and byte order.
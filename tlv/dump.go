@@ -0,0 +1,158 @@
+package tlv
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	dumpIndent       = "  "
+	dumpFlags        = "+-# 0"
+	dumpPrintableMin = ' '
+	dumpPrintableMax = '~'
+)
+
+// DumpOptions controls how [Node.Dump] and [Nodes.Dump] render trees.
+type DumpOptions struct {
+	MaxDepth      int // Levels rendered, the root nodes being the first one (0 means unlimited).
+	MaxValueBytes int // Value bytes previewed per node, the remaining ones are only counted (0 means unlimited).
+}
+
+// DefaultDumpOptions are used when nodes are formatted with the %+v verb.
+var DefaultDumpOptions = DumpOptions{MaxValueBytes: 16}
+
+type dumper struct {
+	buf  bytes.Buffer
+	opts DumpOptions
+}
+
+// Dump writes the node as an indented tree, one line per node with its tag (and name, when there
+// is a schema), its length and either its nested nodes or a hexadecimal and ASCII preview of its value.
+// Values are expanded when they decode as TLV, unless the schema defines them as not nested.
+func (n *Node) Dump(w io.Writer, opts DumpOptions) error {
+	return Nodes{*n}.Dump(w, opts)
+}
+
+// Dump writes the nodes as an indented tree, see [Node.Dump].
+func (ns Nodes) Dump(w io.Writer, opts DumpOptions) error {
+	d := dumper{opts: opts}
+	d.writeNodes(ns, 0)
+
+	_, err := w.Write(d.buf.Bytes())
+	return err
+}
+
+// nodeFields has the fields of a [Node] without its methods, so it is formatted as a plain struct.
+type nodeFields Node
+
+// Format implements [fmt.Formatter], writing the tree with [DefaultDumpOptions] for the %+v verb,
+// the String output for the %v and %s verbs and the default struct formatting for any other verb.
+func (n Node) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		_ = n.Dump(f, DefaultDumpOptions)
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, strings.Replace(fmt.Sprintf("%#v", nodeFields(n)), "tlv.nodeFields", "tlv.Node", 1))
+	case verb == 'v' || verb == 's':
+		fmt.Fprintf(f, formatDirective(f, verb), n.String())
+	default:
+		fmt.Fprintf(f, formatDirective(f, verb), nodeFields(n))
+	}
+}
+
+// Format implements [fmt.Formatter], writing the tree with [DefaultDumpOptions] for the %+v verb
+// and the default slice formatting for any other verb.
+func (ns Nodes) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		_ = ns.Dump(f, DefaultDumpOptions)
+		return
+	}
+
+	fmt.Fprintf(f, formatDirective(f, verb), []Node(ns))
+}
+
+func (d *dumper) writeNodes(nodes Nodes, depth int) {
+	for i := range nodes {
+		d.writeNode(&nodes[i], depth)
+	}
+}
+
+func (d *dumper) writeNode(node *Node, depth int) {
+	d.buf.WriteString(strings.Repeat(dumpIndent, depth))
+	fmt.Fprintf(&d.buf, "%s, %d bytes", node.getSafeDecoder().schema.formatTag(node.Tag), node.Length)
+
+	if children, ok := d.expand(node, depth); ok {
+		d.buf.WriteByte('\n')
+		d.writeNodes(children, depth+1)
+		return
+	}
+
+	d.writeValue(node.Value)
+	d.buf.WriteByte('\n')
+}
+
+// expand returns the nested nodes to be rendered below the node, if any.
+func (d *dumper) expand(node *Node, depth int) (Nodes, bool) {
+	if d.opts.MaxDepth > 0 && depth+1 >= d.opts.MaxDepth {
+		return nil, false
+	}
+
+	children, ok, _ := node.decodeNested()
+	return children, ok
+}
+
+// writeValue writes the value preview as hexadecimal bytes followed by their printable characters.
+func (d *dumper) writeValue(value []byte) {
+	if len(value) == 0 {
+		return
+	}
+
+	preview := value
+	if d.opts.MaxValueBytes > 0 && len(preview) > d.opts.MaxValueBytes {
+		preview = preview[:d.opts.MaxValueBytes]
+	}
+
+	d.buf.WriteString(":")
+	for _, b := range preview {
+		fmt.Fprintf(&d.buf, " %02x", b)
+	}
+
+	d.buf.WriteString(" |")
+	for _, b := range preview {
+		if b < dumpPrintableMin || b > dumpPrintableMax {
+			b = '.'
+		}
+		d.buf.WriteByte(b)
+	}
+	d.buf.WriteString("|")
+
+	if hidden := len(value) - len(preview); hidden > 0 {
+		fmt.Fprintf(&d.buf, " (+%d bytes)", hidden)
+	}
+}
+
+// formatDirective rebuilds the formatting directive (flags, width, precision and verb) being handled.
+func formatDirective(f fmt.State, verb rune) string {
+	var res strings.Builder
+	res.WriteByte('%')
+
+	for _, flag := range dumpFlags {
+		if f.Flag(int(flag)) {
+			res.WriteRune(flag)
+		}
+	}
+
+	if width, ok := f.Width(); ok {
+		res.WriteString(strconv.Itoa(width))
+	}
+
+	if precision, ok := f.Precision(); ok {
+		res.WriteString("." + strconv.Itoa(precision))
+	}
+
+	res.WriteRune(verb)
+	return res.String()
+}
@@ -0,0 +1,101 @@
+package tlv
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const dataDump = `0x1, 71 bytes
+  0x101, 31 bytes
+    0x102, 12 bytes: 48 65 6c 6c 6f 20 74 68 65 72 65 21 |Hello there!|
+    0x103, 3 bytes: bc 61 4e |.aN|
+    0x104, 4 bytes: 60 dc 8f 20 |` + "`" + `.. |
+  0x101, 32 bytes
+    0x102, 10 bytes: 59 6f 75 20 74 68 65 72 65 3f |You there?|
+    0x103, 1 bytes: f0 |.|
+    0x105, 1 bytes: 01 |.|
+    0x104, 4 bytes: 60 3c 67 3b |` + "`" + `<g;|
+`
+
+func TestNodes_Format_WithPlusVerb(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	require.Equal(t, dataDump, fmt.Sprintf("%+v", nodes))
+	require.Equal(t, dataDump, fmt.Sprintf("%+v", &nodes[0]))
+}
+
+func TestNode_Format_WithPlusVerbOnValue(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	require.Equal(t, dataDump, fmt.Sprintf("%+v", nodes[0]))
+	require.Equal(t, nodes[0].String(), fmt.Sprintf("%v", nodes[0]))
+}
+
+func TestNodes_Format_WithOtherVerbs(t *testing.T) {
+	nodes := Nodes{NewNode(0x01, []byte{0x02})}
+
+	require.Equal(t, fmt.Sprintf("%v", []Node(nodes)), fmt.Sprintf("%v", nodes))
+	require.Equal(t, nodes[0].String(), fmt.Sprintf("%v", &nodes[0]))
+	require.Equal(t, fmt.Sprintf("%-10s|", nodes[0].String()), fmt.Sprintf("%-10s|", &nodes[0]))
+}
+
+func TestNode_Format_WithOtherVerbs(t *testing.T) {
+	node, _, err := DecodeSingle([]byte{0x00, 0x01, 0x00, 0x01, 0x02})
+	require.Nil(t, err)
+
+	require.True(t, strings.HasPrefix(fmt.Sprintf("%x", node), "{1 1 02 0001000102 "))
+	require.NotContains(t, fmt.Sprintf("%q", &node), node.String())
+	require.True(t, strings.HasPrefix(fmt.Sprintf("%#v", node), "tlv.Node{Tag:0x1, Length:0x1, Value:[]uint8{0x2}"))
+	require.True(t, strings.HasPrefix(fmt.Sprintf("%#v", Nodes{node}), "[]tlv.Node{tlv.Node{Tag:0x1,"))
+}
+
+func TestNodes_Dump_WithSchemaAndLimits(t *testing.T) {
+	expected := "0x1 (message), 71 bytes\n" +
+		"  0x101 (push_notification), 31 bytes: 01 02 00 0c |....| (+27 bytes)\n" +
+		"  0x101 (push_notification), 32 bytes: 01 02 00 0a |....| (+28 bytes)\n"
+
+	nodes, err := stdDecoder.WithSchema(testSchema).DecodeBytes(data)
+	require.Nil(t, err)
+
+	var buf bytes.Buffer
+	err = nodes.Dump(&buf, DumpOptions{MaxDepth: 2, MaxValueBytes: 4})
+
+	require.Nil(t, err)
+	require.Equal(t, expected, buf.String())
+}
+
+func TestNode_Dump_WhenTheSchemaDefinesAValueAsNotNested(t *testing.T) {
+	value, err := EncodeNode(NewNode(0x0102, []byte{0x00}))
+	require.Nil(t, err)
+
+	schema := MustCreateSchema(TagDefinition{Tag: 0x0001, Name: "raw"})
+	node := stdDecoder.WithSchema(schema).NewNode(0x0001, value)
+
+	var buf bytes.Buffer
+	err = node.Dump(&buf, DumpOptions{})
+
+	require.Nil(t, err)
+	require.Equal(t, "0x1 (raw), 5 bytes: 01 02 00 01 00 |.....|\n", buf.String())
+}
+
+func TestNode_Dump_WhenTheValueIsEmpty(t *testing.T) {
+	node := NewNode(0x0001, nil)
+
+	var buf bytes.Buffer
+	err := node.Dump(&buf, DefaultDumpOptions)
+
+	require.Nil(t, err)
+	require.Equal(t, "0x1, 0 bytes\n", buf.String())
+}
+
+func TestNodes_Dump_WhenTheWriterFails(t *testing.T) {
+	err := Nodes{NewNode(0x0001, nil)}.Dump(new(failingWriter), DefaultDumpOptions)
+
+	require.NotNil(t, err)
+}
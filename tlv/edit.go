@@ -0,0 +1,204 @@
+package tlv
+
+import "fmt"
+
+// editFunc returns the nodes that replace a node selected for editing.
+type editFunc func(node Node) (Nodes, error)
+
+// editor applies an editFunc to the nodes selected by a path, rebuilding their ancestors.
+type editor struct {
+	path    Path
+	fn      editFunc
+	reached int // number of leading segments that selected at least one node
+	edited  int
+}
+
+// SetValue returns a copy of the nodes where the nodes selected by the path (see [Nodes.Find]) have
+// the value. The values of their ancestors are encoded again, with the configuration of the decoder of
+// each ancestor, and the resulting nodes are decoded again, so Length, Raw and offsets are consistent.
+// It fails with a *QueryError wrapping ErrNoMatch if the path selects no nodes.
+// The original nodes and their buffers are not changed.
+func (ns Nodes) SetValue(path Path, value []byte) (Nodes, error) {
+	return ns.edit(path, func(node Node) (Nodes, error) {
+		return Nodes{node.getSafeDecoder().NewNode(node.Tag, value)}, nil
+	})
+}
+
+// Replace works as [Nodes.SetValue], but replaces the nodes selected by the path with the node.
+func (ns Nodes) Replace(path Path, node Node) (Nodes, error) {
+	return ns.edit(path, func(Node) (Nodes, error) {
+		return Nodes{node}, nil
+	})
+}
+
+// Remove works as [Nodes.SetValue], but removes the nodes selected by the path.
+func (ns Nodes) Remove(path Path) (Nodes, error) {
+	return ns.edit(path, func(Node) (Nodes, error) {
+		return nil, nil
+	})
+}
+
+// Insert works as [Nodes.SetValue], but inserts the children at the zero-based index in the values
+// of the nodes selected by the parent path, or among the nodes themselves if the parent path is empty.
+// Parents with an empty value have no children yet.
+// It fails with a *QueryError wrapping ErrInvalidIndex if the index is beyond the existing children.
+func (ns Nodes) Insert(parent Path, index int, children ...Node) (Nodes, error) {
+	if index < 0 {
+		return nil, &QueryError{Path: parent.String(), Segment: len(parent), Err: newIndexError(index, 0)}
+	}
+
+	return ns.insert(parent, index, children)
+}
+
+// Append works as [Nodes.Insert], adding the children after the existing ones.
+func (ns Nodes) Append(parent Path, children ...Node) (Nodes, error) {
+	return ns.insert(parent, -1, children)
+}
+
+func (ns Nodes) insert(parent Path, index int, children Nodes) (Nodes, error) {
+	if len(parent) == 0 {
+		res, err := insertNodes(ns, index, children)
+		if err != nil {
+			return nil, &QueryError{Path: parent.String(), Segment: 0, Err: err}
+		}
+
+		return decodeEdited(res)
+	}
+
+	return ns.edit(parent, func(node Node) (Nodes, error) {
+		var siblings Nodes
+		var err error
+		if len(node.Value) > 0 {
+			if siblings, err = node.GetNodes(); err != nil {
+				return nil, &QueryError{Path: parent.String(), Segment: len(parent) - 1, Err: err}
+			}
+		}
+
+		if siblings, err = insertNodes(siblings, index, children); err != nil {
+			return nil, &QueryError{Path: parent.String(), Segment: len(parent), Err: err}
+		}
+
+		res, err := node.withChildren(siblings)
+		return Nodes{res}, err
+	})
+}
+
+// insertNodes returns a copy of the siblings with the nodes inserted at the index, or appended if it is negative.
+func insertNodes(siblings Nodes, index int, nodes Nodes) (Nodes, error) {
+	if index < 0 {
+		index = len(siblings)
+	}
+
+	if index > len(siblings) {
+		return nil, newIndexError(index, len(siblings))
+	}
+
+	res := make(Nodes, 0, len(siblings)+len(nodes))
+	res = append(res, siblings[:index]...)
+	res = append(res, nodes...)
+
+	return append(res, siblings[index:]...), nil
+}
+
+func newIndexError(index, size int) error {
+	return fmt.Errorf("%w %d (must be between 0 and %d)", ErrInvalidIndex, index, size)
+}
+
+func (ns Nodes) edit(path Path, fn editFunc) (Nodes, error) {
+	e := editor{path: path, fn: fn}
+	if len(path) == 0 {
+		return nil, &QueryError{Path: path.String(), Segment: 0, Err: ErrNoMatch}
+	}
+
+	res, err := e.editSiblings(ns, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.edited == 0 {
+		return nil, &QueryError{Path: path.String(), Segment: e.reached, Err: ErrNoMatch}
+	}
+
+	return decodeEdited(res)
+}
+
+// editSiblings returns a copy of the siblings where the nodes selected by the segment are edited.
+func (e *editor) editSiblings(siblings Nodes, segment int) (Nodes, error) {
+	res := make(Nodes, 0, len(siblings))
+	last := 0
+
+	var err error
+	e.path[segment].forEach(siblings, func(i int) {
+		if err != nil {
+			return
+		}
+
+		var edited Nodes
+		if edited, err = e.editNode(siblings[i], segment); err == nil {
+			res = append(append(res, siblings[last:i]...), edited...)
+			last = i + 1
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(res, siblings[last:]...), nil
+}
+
+// editNode returns the nodes that replace a node selected by the segment.
+func (e *editor) editNode(node Node, segment int) (Nodes, error) {
+	if segment+1 > e.reached {
+		e.reached = segment + 1
+	}
+
+	if segment == len(e.path)-1 {
+		e.edited++
+		return e.fn(node)
+	}
+
+	children, err := node.GetNodes()
+	if err != nil {
+		if e.path[segment].Wildcard {
+			return Nodes{node}, nil
+		}
+		return nil, &QueryError{Path: e.path.String(), Segment: segment, Err: err}
+	}
+
+	edited := e.edited
+	if children, err = e.editSiblings(children, segment+1); err != nil || e.edited == edited {
+		return Nodes{node}, err
+	}
+
+	res, err := node.withChildren(children)
+	return Nodes{res}, err
+}
+
+// withChildren returns a copy of the node whose value holds the encoded children.
+func (n *Node) withChildren(children Nodes) (Node, error) {
+	d := n.getSafeDecoder()
+
+	value, err := d.encoder.EncodeNodes(children)
+	if err != nil {
+		return Node{}, err
+	}
+
+	return d.NewNode(n.Tag, value), nil
+}
+
+// decodeEdited encodes the edited nodes with the decoder of the first one and decodes them again.
+func decodeEdited(nodes Nodes) (Nodes, error) {
+	if len(nodes) == 0 {
+		return Nodes{}, nil
+	}
+
+	d := nodes[0].getSafeDecoder()
+
+	data, err := d.encoder.EncodeNodes(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.decodeBytes(data, 0, nil, nil)
+}
@@ -0,0 +1,198 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildEditedData(first, second func(*Builder) *Builder) []byte {
+	builder := NewBuilder().Begin(tagMessage).Begin(tagPushNotification)
+	builder = first(builder).End().Begin(tagPushNotification)
+
+	res, err := second(builder).End().End().Bytes()
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+func firstNotification(builder *Builder) *Builder {
+	return builder.
+		String(tagTitle, "Hello there!").
+		Value(tagActionID, []byte{0xbc, 0x61, 0x4e}).
+		Uint32(tagTimestamp, 1625067296)
+}
+
+func requireEncoded(t *testing.T, expected []byte, nodes Nodes) {
+	res, err := EncodeNodes(nodes)
+	require.Nil(t, err)
+	require.Equal(t, expected, res)
+
+	decoded, err := DecodeBytes(expected)
+	require.Nil(t, err)
+	require.Equal(t, decoded, nodes)
+}
+
+func TestNodes_SetValue(t *testing.T) {
+	original := append([]byte{}, data...)
+	nodes, err := DecodeBytes(original)
+	require.Nil(t, err)
+
+	path := Path{MatchTag(tagMessage), MatchIndex(tagPushNotification, 1), MatchTag(tagTitle)}
+
+	res, err := nodes.SetValue(path, []byte("Hey"))
+	require.Nil(t, err)
+
+	requireEncoded(t, buildEditedData(firstNotification, func(builder *Builder) *Builder {
+		return builder.String(tagTitle, "Hey").Uint8(tagActionID, 240).Bool(tagSilent, true).Uint32(tagTimestamp, 1614571323)
+	}), res)
+
+	require.Equal(t, Length(64), res[0].Length)
+	require.Equal(t, data, original)
+}
+
+func TestNodes_SetValue_WithWildcards(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	path, err := ParsePath("0x1/*/0x105")
+	require.Nil(t, err)
+
+	res, err := nodes.SetValue(path, []byte{0x00})
+	require.Nil(t, err)
+
+	silent, err := res.Query("0x1/0x101/0x105")
+	require.Nil(t, err)
+	require.Len(t, silent, 1)
+	require.Equal(t, []byte{0x00}, silent[0].Value)
+}
+
+func TestNodes_Replace(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Replace(NewPath(tagMessage, tagPushNotification, tagActionID), NewUint8Node(tagSilent, 0))
+	require.Nil(t, err)
+
+	requireEncoded(t, buildEditedData(func(builder *Builder) *Builder {
+		return builder.String(tagTitle, "Hello there!").Uint8(tagSilent, 0).Uint32(tagTimestamp, 1625067296)
+	}, func(builder *Builder) *Builder {
+		return builder.
+			String(tagTitle, "You there?").Uint8(tagSilent, 0).Bool(tagSilent, true).Uint32(tagTimestamp, 1614571323)
+	}), res)
+}
+
+func TestNodes_Remove(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Remove(Path{MatchTag(tagMessage), MatchIndex(tagPushNotification, 0)})
+	require.Nil(t, err)
+
+	children, err := res[0].GetNodes()
+	require.Nil(t, err)
+	require.Len(t, children, 1)
+	require.Equal(t, data[39:], children[0].Raw)
+	require.Equal(t, uint64(4), children[0].GetOffset())
+}
+
+func TestNodes_Insert(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Insert(Path{MatchTag(tagMessage), MatchIndex(tagPushNotification, 1)}, 0, NewStringNode(0x106, "id"))
+	require.Nil(t, err)
+
+	requireEncoded(t, buildEditedData(firstNotification, func(builder *Builder) *Builder {
+		return builder.String(0x106, "id").
+			String(tagTitle, "You there?").Uint8(tagActionID, 240).Bool(tagSilent, true).Uint32(tagTimestamp, 1614571323)
+	}), res)
+}
+
+func TestNodes_Append(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Append(nil, NewUint8Node(0x02, 1))
+	require.Nil(t, err)
+
+	require.Len(t, res, 2)
+	require.Equal(t, uint64(75), res[1].GetOffset())
+
+	res, err = Nodes{}.Append(nil, NewUint8Node(0x02, 1))
+	require.Nil(t, err)
+	require.Len(t, res, 1)
+}
+
+func TestNodes_Append_WhenTheParentIsEmpty(t *testing.T) {
+	nodes, err := NewBuilder().Begin(tagMessage).End().Nodes()
+	require.Nil(t, err)
+
+	res, err := nodes.Append(NewPath(tagMessage), NewUint8Node(0x02, 1))
+	require.Nil(t, err)
+
+	children, err := res[0].GetNodes()
+	require.Nil(t, err)
+	require.Len(t, children, 1)
+	require.Equal(t, Tag(0x02), children[0].Tag)
+
+	_, err = nodes.Insert(NewPath(tagMessage), 1, NewUint8Node(0x02, 1))
+	require.True(t, errors.Is(err, ErrInvalidIndex))
+}
+
+func TestNodes_Insert_WhenTheIndexIsInvalid(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	for _, index := range []int{-1, 4} {
+		_, err = nodes.Insert(NewPath(tagMessage, tagPushNotification), index, NewUint8Node(0x02, 1))
+
+		var queryErr *QueryError
+		require.True(t, errors.As(err, &queryErr))
+		require.True(t, errors.Is(err, ErrInvalidIndex))
+		require.Equal(t, 2, queryErr.Segment)
+	}
+}
+
+func TestNodes_SetValue_WhenNothingMatches(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	_, err = nodes.SetValue(NewPath(tagMessage, 0x999, tagTitle), nil)
+
+	var queryErr *QueryError
+	require.True(t, errors.As(err, &queryErr))
+	require.True(t, errors.Is(err, ErrNoMatch))
+	require.Equal(t, 1, queryErr.Segment)
+
+	_, err = nodes.Remove(nil)
+	require.True(t, errors.Is(err, ErrNoMatch))
+}
+
+func TestNodes_SetValue_WhenTheValueIsInvalid(t *testing.T) {
+	nodes := Nodes{NewNode(0x01, []byte{0x00, 0x02, 0x00, 0x05})}
+
+	_, err := nodes.SetValue(NewPath(0x01, 0x02), nil)
+
+	require.True(t, errors.Is(err, ErrLengthMismatch))
+}
+
+func TestNodes_SetValue_KeepsTheDecoderConfiguration(t *testing.T) {
+	decoder := MustCreateDecoder(1, 1, binary.LittleEndian)
+
+	nodes, err := decoder.DecodeBytes([]byte{0x01, 0x03, 0x02, 0x01, 0x00})
+	require.Nil(t, err)
+
+	res, err := nodes.SetValue(NewPath(0x01, 0x02), decoder.NewUint16Node(0, 0x1234).Value)
+	require.Nil(t, err)
+
+	require.Equal(t, []byte{0x01, 0x04, 0x02, 0x02, 0x34, 0x12}, res[0].Raw)
+
+	children, err := res[0].GetNodes()
+	require.Nil(t, err)
+	require.Equal(t, uint16(0x1234), children[0].GetPaddedUint16())
+}
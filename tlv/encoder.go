@@ -0,0 +1,128 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Encoder is a configurable TLV encoder instance.
+type Encoder interface {
+	// EncodeNode encodes a single TLV [Node] to a byte array.
+	EncodeNode(node Node) ([]byte, error)
+	// EncodeNodes encodes a list of TLV [Nodes] to a byte array.
+	EncodeNodes(nodes Nodes) ([]byte, error)
+	// WriteNode encodes a single TLV [Node] to an [io.Writer].
+	WriteNode(writer io.Writer, node Node) (written uint64, err error)
+	// WriteNodes encodes a list of TLV [Nodes] to an [io.Writer].
+	WriteNodes(writer io.Writer, nodes Nodes) (written uint64, err error)
+	// Marshal encodes a struct annotated with `tlv` struct tags to a byte array.
+	Marshal(v interface{}) ([]byte, error)
+	// GetByteOrder returns the encoder endianness configuration.
+	GetByteOrder() binary.ByteOrder
+}
+
+type encoder struct {
+	header    headerFormat
+	byteOrder binary.ByteOrder
+}
+
+// MustCreateEncoder creates an [Encoder] using custom configuration or panics in case of any errors.
+func MustCreateEncoder(tagSize, lengthSize uint8, byteOrder binary.ByteOrder) Encoder {
+	res, err := CreateEncoder(tagSize, lengthSize, byteOrder)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// CreateEncoder creates an [Encoder] using custom configuration.
+// Hint: tagSize and lengthSize must be numbers between 1 and 8.
+func CreateEncoder(tagSize, lengthSize uint8, byteOrder binary.ByteOrder) (Encoder, error) {
+	if err := validateSizes(tagSize, lengthSize); err != nil {
+		return nil, err
+	}
+
+	return newFixedDecoder(tagSize, lengthSize, byteOrder).encoder, nil
+}
+
+func newEncoder(header headerFormat, byteOrder binary.ByteOrder) *encoder {
+	return &encoder{header: header, byteOrder: byteOrder}
+}
+
+// EncodeNode encodes a single [Node] as TLV bytes.
+// Note: the length is always taken from the node value, so the Length field is ignored.
+func (e *encoder) EncodeNode(node Node) ([]byte, error) {
+	return e.appendNode(make([]byte, 0, e.getNodeSize(node)), node)
+}
+
+// EncodeNodes encodes a list of [Nodes] as TLV bytes.
+func (e *encoder) EncodeNodes(nodes Nodes) ([]byte, error) {
+	size := 0
+	for i := range nodes {
+		size += e.getNodeSize(nodes[i])
+	}
+
+	res := make([]byte, 0, size)
+	for i := range nodes {
+		var err error
+		if res, err = e.appendNode(res, nodes[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// WriteNode encodes a single [Node] as TLV bytes to an [io.Writer].
+func (e *encoder) WriteNode(writer io.Writer, node Node) (written uint64, err error) {
+	header, err := e.appendHeader(make([]byte, 0, e.getNodeSize(node)-len(node.Value)), node)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := writer.Write(header)
+	if err != nil {
+		return uint64(n), err
+	}
+
+	m, err := writer.Write(node.Value)
+
+	return uint64(n + m), err
+}
+
+// WriteNodes encodes a list of [Nodes] as TLV bytes to an [io.Writer].
+func (e *encoder) WriteNodes(writer io.Writer, nodes Nodes) (written uint64, err error) {
+	for i := range nodes {
+		n, err := e.WriteNode(writer, nodes[i])
+		written += n
+
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// GetByteOrder returns the [Encoder] endianness configuration.
+func (e *encoder) GetByteOrder() binary.ByteOrder {
+	return e.byteOrder
+}
+
+func (e *encoder) appendNode(dst []byte, node Node) ([]byte, error) {
+	res, err := e.appendHeader(dst, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(res, node.Value...), nil
+}
+
+func (e *encoder) appendHeader(dst []byte, node Node) ([]byte, error) {
+	return e.header.append(dst, node.Tag, uint64(len(node.Value)))
+}
+
+func (e *encoder) getNodeSize(node Node) int {
+	return e.header.size(node.Tag, uint64(len(node.Value))) + len(node.Value)
+}
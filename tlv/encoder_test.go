@@ -0,0 +1,137 @@
+package tlv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (*failingWriter) Write(_ []byte) (n int, err error) {
+	return 0, errors.New("forcing writer error")
+}
+
+func TestMustCreateEncoder_WhenTheSizesAreInvalid(t *testing.T) {
+	defer func() {
+		require.NotNil(t, recover())
+	}()
+
+	_ = MustCreateEncoder(0, 0, binary.BigEndian)
+}
+
+func TestCreateEncoder_WhenTheTagSizeIsTooBig(t *testing.T) {
+	e, err := CreateEncoder(10, 2, binary.BigEndian)
+
+	require.Nil(t, e)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "tag size")
+}
+
+func TestCreateEncoder_WhenTheLengthSizeIsTooSmall(t *testing.T) {
+	e, err := CreateEncoder(2, 0, binary.BigEndian)
+
+	require.Nil(t, e)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "length size")
+}
+
+func TestEncoder_EncodeNodes_RoundTrip(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := EncodeNodes(nodes)
+
+	require.Nil(t, err)
+	require.Equal(t, data, res)
+}
+
+func TestEncoder_EncodeNodes_RoundTripWithCustomConfiguration(t *testing.T) {
+	d := MustCreateDecoder(3, 1, binary.LittleEndian)
+	raw := []byte{
+		0x03, 0x02, 0x01, 0x07, // tag 0x010203, length 7
+		0x05, 0x00, 0x00, 0x02, // tag 0x000005, length 2
+		0xab, 0xcd, // value
+		0x00, // trailing value byte
+	}
+
+	nodes, err := d.DecodeBytes(raw)
+	require.Nil(t, err)
+	require.Equal(t, Tag(0x010203), nodes[0].Tag)
+	require.Equal(t, Length(7), nodes[0].Length)
+
+	res, err := d.GetEncoder().EncodeNodes(nodes)
+
+	require.Nil(t, err)
+	require.Equal(t, raw, res)
+}
+
+func TestEncoder_EncodeNode(t *testing.T) {
+	node := NewNode(0x0102, []byte("abc"))
+
+	res, err := EncodeNode(node)
+
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0x00, 0x03, 'a', 'b', 'c'}, res)
+}
+
+func TestEncoder_EncodeNode_WhenTheTagDoesNotFit(t *testing.T) {
+	node := NewNode(0x010203, nil)
+
+	res, err := EncodeNode(node)
+
+	require.Nil(t, res)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "tag 0x10203")
+}
+
+func TestEncoder_EncodeNodes_WhenTheLengthDoesNotFit(t *testing.T) {
+	e := MustCreateEncoder(1, 1, binary.BigEndian)
+	nodes := Nodes{NewNode(0x01, nil), NewNode(0x02, make([]byte, 256))}
+
+	res, err := e.EncodeNodes(nodes)
+
+	require.Nil(t, res)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "value length 256")
+}
+
+func TestEncoder_WriteNodes(t *testing.T) {
+	nodes, err := DecodeBytes(append(append([]byte{}, data...), data...))
+	require.Nil(t, err)
+
+	var buf bytes.Buffer
+	written, err := WriteNodes(&buf, nodes)
+
+	require.Nil(t, err)
+	require.Equal(t, uint64(2*len(data)), written)
+	require.Equal(t, append(append([]byte{}, data...), data...), buf.Bytes())
+}
+
+func TestEncoder_WriteNodes_WhenTheWriterFails(t *testing.T) {
+	nodes := Nodes{NewNode(0x01, []byte{0x01})}
+
+	written, err := WriteNodes(new(failingWriter), nodes)
+
+	require.NotNil(t, err)
+	require.Zero(t, written)
+}
+
+func TestEncoder_WriteNode_WhenTheTagDoesNotFit(t *testing.T) {
+	var buf bytes.Buffer
+
+	written, err := stdEncoder.WriteNode(&buf, NewNode(0x010203, nil))
+
+	require.NotNil(t, err)
+	require.Zero(t, written)
+	require.Zero(t, buf.Len())
+}
+
+func TestEncoder_GetByteOrder(t *testing.T) {
+	e := MustCreateEncoder(1, 1, binary.LittleEndian)
+
+	require.Equal(t, binary.LittleEndian, e.GetByteOrder())
+}
@@ -0,0 +1,49 @@
+package tlv
+
+import "bytes"
+
+// Equal returns whether the nodes have the same tag and value, regardless of how they were decoded
+// or which buffers they reference. Different values are still equal when both nodes are nested
+// (see [Node.IsNested]) and their values decode as equal nested nodes, e.g. when encoded with
+// different header sizes. Any other values are compared byte by byte.
+func (n *Node) Equal(other *Node) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+
+	if n.Tag != other.Tag {
+		return false
+	}
+
+	if bytes.Equal(n.Value, other.Value) {
+		return true
+	}
+
+	if !n.IsNested() || !other.IsNested() {
+		return false
+	}
+
+	children, err := n.decodeChildren()
+	if err != nil {
+		return false
+	}
+
+	otherChildren, err := other.decodeChildren()
+	return err == nil && children.Equal(otherChildren)
+}
+
+// Equal returns whether both lists have equal nodes (see [Node.Equal]) in the same order.
+// Use [Nodes.Canonicalize] first to ignore the order of siblings.
+func (ns Nodes) Equal(other Nodes) bool {
+	if len(ns) != len(other) {
+		return false
+	}
+
+	for i := range ns {
+		if !ns[i].Equal(&other[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode_Equal(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	node := NewNode(tagMessage, data[4:])
+	other := NewNode(tagPushNotification, data[4:])
+
+	require.True(t, nodes[0].Equal(&node))
+	require.True(t, node.Equal(&nodes[0]))
+	require.False(t, nodes[0].Equal(&other))
+	require.False(t, nodes[0].Equal(nil))
+
+	title := NewNode(tagTitle, []byte("a"))
+	require.False(t, title.Equal(&Node{Tag: tagTitle, Value: []byte("b")}))
+}
+
+func TestNode_Equal_WithDifferentHeaderSizes(t *testing.T) {
+	decoder := MustCreateDecoder(2, 1, binary.BigEndian).WithSchema(testSchema)
+
+	other, err := decoder.NewBuilder().Begin(tagPushNotification).String(tagTitle, "title").End().Nodes()
+	require.Nil(t, err)
+
+	nodes, err := stdDecoder.WithSchema(testSchema).NewBuilder().
+		Begin(tagPushNotification).String(tagTitle, "title").End().Nodes()
+	require.Nil(t, err)
+
+	require.NotEqual(t, nodes[0].Value, other[0].Value)
+	require.True(t, nodes[0].Equal(&other[0]))
+
+	changed, err := stdDecoder.WithSchema(testSchema).NewBuilder().
+		Begin(tagPushNotification).String(tagTitle, "other").End().Nodes()
+	require.Nil(t, err)
+	require.False(t, changed[0].Equal(&other[0]))
+}
+
+func TestNode_Equal_WhenTheValuesAreNotNested(t *testing.T) {
+	a := NewNode(0x10, []byte{0x00, 0x01, 0x00, 0x01, 'a'})
+	b := MustCreateDecoder(1, 1, binary.BigEndian).NewNode(0x10, []byte{0x01, 0x01, 'a'})
+
+	require.False(t, a.Equal(&b))
+}
+
+func TestNodes_Equal(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	children, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	require.True(t, children.Equal(children.Clone()))
+	require.False(t, children.Equal(children[:1]))
+	require.False(t, children.Equal(Nodes{children[1], children[0]}))
+	require.True(t, Nodes{}.Equal(nil))
+}
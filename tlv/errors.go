@@ -0,0 +1,464 @@
+package tlv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors wrapped by the typed errors of this package, to be used with [errors.Is].
+var (
+	ErrInvalidSize       = errors.New("invalid size")
+	ErrMessageTooShort   = errors.New("message is too short")
+	ErrLengthMismatch    = errors.New("value length mismatch")
+	ErrMalformedHeader   = errors.New("malformed header")
+	ErrInvalidTag        = errors.New("invalid tag")
+	ErrOverflow          = errors.New("value overflow")
+	ErrInvalidTarget     = errors.New("invalid target")
+	ErrInvalidStructTag  = errors.New("invalid struct tag")
+	ErrUnsupportedType   = errors.New("unsupported type")
+	ErrInvalidPath       = errors.New("invalid path segment")
+	ErrSchemaViolation   = errors.New("schema violation")
+	ErrDuplicateTag      = errors.New("duplicate tag")
+	ErrInvalidTree       = errors.New("invalid tree")
+	ErrPartialDecode     = errors.New("partial decode")
+	ErrValueTooLong      = errors.New("value too long")
+	ErrTooManyNodes      = errors.New("too many nodes")
+	ErrTooDeep           = errors.New("nesting too deep")
+	ErrReaderTooLarge    = errors.New("reader too large")
+	ErrTrailingData      = errors.New("trailing data")
+	ErrEmptyValue        = errors.New("empty value")
+	ErrZeroTag           = errors.New("zero tag")
+	ErrUnbalancedBuilder = errors.New("unbalanced builder")
+	ErrNoMatch           = errors.New("no matching nodes")
+	ErrInvalidIndex      = errors.New("invalid index")
+)
+
+// InvalidSizeError is returned when a tag or length size is out of the allowed range.
+type InvalidSizeError struct {
+	Field string
+	Size  uint8
+	Min   uint8
+	Max   uint8
+}
+
+func (e *InvalidSizeError) Error() string {
+	return fmt.Sprintf("invalid %s size: %d (must be between %d and %d)", e.Field, e.Size, e.Min, e.Max)
+}
+
+func (e *InvalidSizeError) Unwrap() error {
+	return ErrInvalidSize
+}
+
+// MessageTooShortError is returned when there are not enough bytes left for a node header.
+type MessageTooShortError struct {
+	Offset    uint64 // Offset of the node in the original buffer.
+	Available uint64 // Bytes available from the offset onwards.
+	Path      []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *MessageTooShortError) Error() string {
+	return fmt.Sprintf(
+		"message is too short (%d bytes), data may be corrupted %s",
+		e.Available, formatLocation(e.Offset, e.Path, e.schema),
+	)
+}
+
+func (e *MessageTooShortError) Unwrap() error {
+	return ErrMessageTooShort
+}
+
+// LengthMismatchError is returned when a node declares a length bigger than the bytes available.
+type LengthMismatchError struct {
+	Offset    uint64 // Offset of the node in the original buffer.
+	Tag       Tag    // Tag of the node being decoded.
+	Length    Length // Length declared in the node header.
+	Available uint64 // Value bytes available after the node header.
+	Path      []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *LengthMismatchError) Error() string {
+	return fmt.Sprintf(
+		"value length mismatch for tag %s, expected %d bytes but only %d bytes are available, data may be corrupted %s",
+		e.schema.formatTag(e.Tag), e.Length, e.Available, formatLocation(e.Offset, e.Path, e.schema),
+	)
+}
+
+func (e *LengthMismatchError) Unwrap() error {
+	return ErrLengthMismatch
+}
+
+// MalformedHeaderError is returned when a node header cannot be parsed (e.g. unsupported BER forms).
+type MalformedHeaderError struct {
+	Offset uint64 // Offset of the node in the original buffer.
+	Reason string // Description of the problem found in the header.
+	Path   []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *MalformedHeaderError) Error() string {
+	return fmt.Sprintf("malformed header, %s %s", e.Reason, formatLocation(e.Offset, e.Path, e.schema))
+}
+
+func (e *MalformedHeaderError) Unwrap() error {
+	return ErrMalformedHeader
+}
+
+// InvalidTagError is returned when a tag cannot be encoded in the configured format.
+type InvalidTagError struct {
+	Tag Tag
+}
+
+func (e *InvalidTagError) Error() string {
+	return fmt.Sprintf("tag 0x%x is not valid in the encoder format", uint64(e.Tag))
+}
+
+func (e *InvalidTagError) Unwrap() error {
+	return ErrInvalidTag
+}
+
+// OverflowError is returned when a tag or length does not fit the configured size during encoding.
+type OverflowError struct {
+	Field string
+	Value uint64
+	Size  uint8
+}
+
+func (e *OverflowError) Error() string {
+	if e.Field == fieldTag {
+		return fmt.Sprintf("tag 0x%x does not fit in %d bytes", e.Value, e.Size)
+	}
+
+	return fmt.Sprintf("value %s %d does not fit in %d bytes", e.Field, e.Value, e.Size)
+}
+
+func (e *OverflowError) Unwrap() error {
+	return ErrOverflow
+}
+
+// InvalidTargetError is returned when the value given to Marshal or Unmarshal has an unexpected type.
+type InvalidTargetError struct {
+	Type     string
+	Expected string
+}
+
+func (e *InvalidTargetError) Error() string {
+	return fmt.Sprintf("invalid target type %s, expected %s", e.Type, e.Expected)
+}
+
+func (e *InvalidTargetError) Unwrap() error {
+	return ErrInvalidTarget
+}
+
+// StructTagError is returned when a `tlv` struct tag cannot be parsed.
+type StructTagError struct {
+	Field string
+	Tag   string
+}
+
+func (e *StructTagError) Error() string {
+	return fmt.Sprintf("invalid tlv struct tag %q on field %s", e.Tag, e.Field)
+}
+
+func (e *StructTagError) Unwrap() error {
+	return ErrInvalidStructTag
+}
+
+// UnsupportedTypeError is returned when a struct field type cannot be mapped to a TLV value.
+type UnsupportedTypeError struct {
+	Field string
+	Type  string
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("unsupported type %s on field %s", e.Type, e.Field)
+}
+
+func (e *UnsupportedTypeError) Unwrap() error {
+	return ErrUnsupportedType
+}
+
+// ValueOverflowError is returned when a decoded value does not fit the struct field type.
+type ValueOverflowError struct {
+	Field string
+	Value interface{} // Decoded value as uint64, int64 or float64.
+	Type  string
+}
+
+func (e *ValueOverflowError) Error() string {
+	return fmt.Sprintf("value %v overflows %s on field %s", e.Value, e.Type, e.Field)
+}
+
+func (e *ValueOverflowError) Unwrap() error {
+	return ErrOverflow
+}
+
+// QueryError is returned when a path cannot be parsed or the nodes selected by one of its
+// segments cannot be decoded to reach the next one.
+type QueryError struct {
+	Path    string // Path expression being queried.
+	Segment int    // Zero-based index of the failing segment.
+	Err     error  // Syntax or decoding error found.
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("query %s failed at segment %d: %v", e.Path, e.Segment, e.Err)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// SchemaError is returned when a node does not match its definition in a [Schema].
+type SchemaError struct {
+	Offset uint64 // Offset of the node in the original buffer.
+	Tag    Tag    // Tag of the invalid node.
+	Reason string // Description of the violation.
+	Path   []Tag  // Tags of the parent nodes.
+
+	schema *Schema
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf(
+		"schema violation for tag %s, %s %s",
+		e.schema.formatTag(e.Tag), e.Reason, formatLocation(e.Offset, e.Path, e.schema),
+	)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return ErrSchemaViolation
+}
+
+// DuplicateDefinitionError is returned when a tag is registered twice in a [Schema].
+type DuplicateDefinitionError struct {
+	Tag Tag
+}
+
+func (e *DuplicateDefinitionError) Error() string {
+	return fmt.Sprintf("tag 0x%x is already defined in the schema", uint64(e.Tag))
+}
+
+func (e *DuplicateDefinitionError) Unwrap() error {
+	return ErrDuplicateTag
+}
+
+// InvalidTreeError is returned when a [TreeNode] cannot be converted back to TLV bytes.
+type InvalidTreeError struct {
+	Tag    string // Tag of the tree node, as found in the input.
+	Reason string // Description of the problem found in the tree node.
+}
+
+func (e *InvalidTreeError) Error() string {
+	return fmt.Sprintf("invalid tree node with tag %q, %s", e.Tag, e.Reason)
+}
+
+func (e *InvalidTreeError) Unwrap() error {
+	return ErrInvalidTree
+}
+
+// PartialDecodeError is returned by [Decoder.DecodePartial] when some root nodes could not be decoded.
+type PartialDecodeError struct {
+	Decoded  int             // Number of nodes decoded successfully.
+	Failures []DecodeFailure // Failures in the order they were found.
+}
+
+func (e *PartialDecodeError) Error() string {
+	return fmt.Sprintf(
+		"partial decode, %d nodes decoded and %d failed, first failure: %s",
+		e.Decoded, len(e.Failures), e.Failures[0].Reason,
+	)
+}
+
+func (e *PartialDecodeError) Unwrap() error {
+	return ErrPartialDecode
+}
+
+// ValueTooLongError is returned when a node declares a value length above [Limits].MaxValueLength.
+type ValueTooLongError struct {
+	Offset uint64 // Offset of the node in the original buffer.
+	Tag    Tag    // Tag of the node being decoded.
+	Length Length // Length declared in the node header.
+	Max    uint64 // Configured limit.
+	Path   []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *ValueTooLongError) Error() string {
+	return fmt.Sprintf(
+		"value length %d for tag %s exceeds the limit of %d bytes %s",
+		e.Length, e.schema.formatTag(e.Tag), e.Max, formatLocation(e.Offset, e.Path, e.schema),
+	)
+}
+
+func (e *ValueTooLongError) Unwrap() error {
+	return ErrValueTooLong
+}
+
+// TooManyNodesError is returned when decoding more nodes than [Limits].MaxNodes.
+type TooManyNodesError struct {
+	Offset uint64 // Offset of the first node beyond the limit.
+	Max    int    // Configured limit.
+	Path   []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *TooManyNodesError) Error() string {
+	return fmt.Sprintf(
+		"too many nodes, the limit of %d nodes was reached %s", e.Max, formatLocation(e.Offset, e.Path, e.schema),
+	)
+}
+
+func (e *TooManyNodesError) Unwrap() error {
+	return ErrTooManyNodes
+}
+
+// TooDeepError is returned when decoding nodes nested deeper than [Limits].MaxDepth.
+type TooDeepError struct {
+	Offset uint64 // Offset of the nested value in the original buffer.
+	Depth  int    // Nesting level of the nodes being decoded.
+	Max    int    // Configured limit.
+	Path   []Tag  // Tags of the parent nodes.
+
+	schema *Schema
+}
+
+func (e *TooDeepError) Error() string {
+	return fmt.Sprintf(
+		"nesting depth %d exceeds the limit of %d %s", e.Depth, e.Max, formatLocation(e.Offset, e.Path, e.schema),
+	)
+}
+
+func (e *TooDeepError) Unwrap() error {
+	return ErrTooDeep
+}
+
+// ReaderTooLargeError is returned when a reader holds more bytes than [Limits].MaxReaderSize.
+type ReaderTooLargeError struct {
+	Max int64 // Configured limit.
+}
+
+func (e *ReaderTooLargeError) Error() string {
+	return fmt.Sprintf("reader data exceeds the limit of %d bytes", e.Max)
+}
+
+func (e *ReaderTooLargeError) Unwrap() error {
+	return ErrReaderTooLarge
+}
+
+// TrailingDataError is returned in strict mode when there are bytes left after the last node.
+type TrailingDataError struct {
+	Offset uint64 // Offset of the trailing bytes in the original buffer.
+	Size   uint64 // Number of trailing bytes.
+	Path   []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *TrailingDataError) Error() string {
+	return fmt.Sprintf(
+		"%d bytes of trailing data after the last node %s", e.Size, formatLocation(e.Offset, e.Path, e.schema),
+	)
+}
+
+func (e *TrailingDataError) Unwrap() error {
+	return ErrTrailingData
+}
+
+// EmptyValueError is returned in strict mode when a node has a zero-length value for a tag that requires one.
+type EmptyValueError struct {
+	Offset uint64 // Offset of the node in the original buffer.
+	Tag    Tag    // Tag of the node.
+	Path   []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *EmptyValueError) Error() string {
+	return fmt.Sprintf("empty value for tag %s %s", e.schema.formatTag(e.Tag), formatLocation(e.Offset, e.Path, e.schema))
+}
+
+func (e *EmptyValueError) Unwrap() error {
+	return ErrEmptyValue
+}
+
+// DuplicateNodeError is returned in strict mode when sibling nodes have the same tag.
+type DuplicateNodeError struct {
+	Offset      uint64 // Offset of the repeated node in the original buffer.
+	FirstOffset uint64 // Offset of the first node with the same tag.
+	Tag         Tag    // Repeated tag.
+	Path        []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *DuplicateNodeError) Error() string {
+	return fmt.Sprintf(
+		"duplicate tag %s, first found at offset %d, repeated %s",
+		e.schema.formatTag(e.Tag), e.FirstOffset, formatLocation(e.Offset, e.Path, e.schema),
+	)
+}
+
+func (e *DuplicateNodeError) Unwrap() error {
+	return ErrDuplicateTag
+}
+
+// ZeroTagError is returned in strict mode when a node has a zero tag.
+type ZeroTagError struct {
+	Offset uint64 // Offset of the node in the original buffer.
+	Path   []Tag  // Tags of the parent nodes, when decoding nested values.
+
+	schema *Schema
+}
+
+func (e *ZeroTagError) Error() string {
+	return fmt.Sprintf("zero tag %s", formatLocation(e.Offset, e.Path, e.schema))
+}
+
+func (e *ZeroTagError) Unwrap() error {
+	return ErrZeroTag
+}
+
+// UnbalancedBuilderError is returned by a [Builder] when End has no matching Begin
+// or when the message is finished with nodes started by Begin and not ended.
+type UnbalancedBuilderError struct {
+	Open []Tag // Tags of the nodes not ended, empty when End had no matching Begin.
+
+	schema *Schema
+}
+
+func (e *UnbalancedBuilderError) Error() string {
+	if len(e.Open) == 0 {
+		return "unbalanced builder, End called without a matching Begin"
+	}
+
+	return fmt.Sprintf("unbalanced builder, nodes not ended: %s", formatPath(e.Open, e.schema))
+}
+
+func (e *UnbalancedBuilderError) Unwrap() error {
+	return ErrUnbalancedBuilder
+}
+
+func formatLocation(offset uint64, path []Tag, schema *Schema) string {
+	if len(path) == 0 {
+		return fmt.Sprintf("at offset %d", offset)
+	}
+
+	return fmt.Sprintf("at offset %d under %s", offset, formatPath(path, schema))
+}
+
+func formatPath(path []Tag, schema *Schema) string {
+	parts := make([]string, len(path))
+	for i, tag := range path {
+		parts[i] = schema.formatPathTag(tag)
+	}
+
+	return strings.Join(parts, "/")
+}
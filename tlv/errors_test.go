@@ -0,0 +1,189 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidSizeError(t *testing.T) {
+	err := &InvalidSizeError{Field: "field", Size: 1, Min: 2, Max: 3}
+
+	require.Equal(t, "invalid field size: 1 (must be between 2 and 3)", err.Error())
+	require.True(t, errors.Is(err, ErrInvalidSize))
+}
+
+func TestLengthMismatchError(t *testing.T) {
+	expected := "value length mismatch for tag 0x102, expected 5 bytes but only 4 bytes are available, " +
+		"data may be corrupted at offset 8 under 0x1/0x101"
+
+	err := &LengthMismatchError{Offset: 8, Tag: 0x0102, Length: 5, Available: 4, Path: []Tag{0x0001, 0x0101}}
+
+	require.Equal(t, expected, err.Error())
+	require.True(t, errors.Is(err, ErrLengthMismatch))
+}
+
+func TestMessageTooShortError(t *testing.T) {
+	err := &MessageTooShortError{Offset: 12, Available: 3}
+
+	require.Equal(t, "message is too short (3 bytes), data may be corrupted at offset 12", err.Error())
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+}
+
+func TestOverflowError(t *testing.T) {
+	tagErr := &OverflowError{Field: fieldTag, Value: 0x1234, Size: 1}
+	lengthErr := &OverflowError{Field: fieldLength, Value: 300, Size: 1}
+
+	require.Equal(t, "tag 0x1234 does not fit in 1 bytes", tagErr.Error())
+	require.Equal(t, "value length 300 does not fit in 1 bytes", lengthErr.Error())
+	require.True(t, errors.Is(tagErr, ErrOverflow))
+}
+
+func TestInvalidTargetError(t *testing.T) {
+	err := &InvalidTargetError{Type: "string", Expected: "a struct"}
+
+	require.Equal(t, "invalid target type string, expected a struct", err.Error())
+	require.True(t, errors.Is(err, ErrInvalidTarget))
+}
+
+func TestStructTagError(t *testing.T) {
+	err := &StructTagError{Field: "Title", Tag: "abc"}
+
+	require.Equal(t, `invalid tlv struct tag "abc" on field Title`, err.Error())
+	require.True(t, errors.Is(err, ErrInvalidStructTag))
+}
+
+func TestUnsupportedTypeError(t *testing.T) {
+	err := &UnsupportedTypeError{Field: "Data", Type: "map[string]int"}
+
+	require.Equal(t, "unsupported type map[string]int on field Data", err.Error())
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func TestValueOverflowError(t *testing.T) {
+	err := &ValueOverflowError{Field: "Count", Value: uint64(300), Type: "uint8"}
+
+	require.Equal(t, "value 300 overflows uint8 on field Count", err.Error())
+	require.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestDecodeBytes_ErrorsCarryTheOffset(t *testing.T) {
+	corrupted := append(append([]byte{}, data...), data[:len(data)-5]...)
+
+	_, err := DecodeBytes(corrupted)
+
+	var mismatch *LengthMismatchError
+	require.True(t, errors.As(err, &mismatch))
+	require.Equal(t, uint64(len(data)), mismatch.Offset)
+	require.Equal(t, tagMessage, mismatch.Tag)
+	require.Equal(t, Length(71), mismatch.Length)
+	require.Equal(t, uint64(66), mismatch.Available)
+	require.Empty(t, mismatch.Path)
+}
+
+func TestNode_GetNodes_ErrorsCarryTheNestingPath(t *testing.T) {
+	corrupted := append([]byte{}, data...)
+	corrupted[11] = 0xff // title length
+
+	message, _, err := DecodeSingle(corrupted)
+	require.Nil(t, err)
+	items, err := message.GetNodes()
+	require.Nil(t, err)
+
+	_, err = items[0].GetNodes()
+
+	var mismatch *LengthMismatchError
+	require.True(t, errors.As(err, &mismatch))
+	require.Equal(t, uint64(8), mismatch.Offset)
+	require.Equal(t, tagTitle, mismatch.Tag)
+	require.Equal(t, Length(0xff), mismatch.Length)
+	require.Equal(t, []Tag{tagMessage, tagPushNotification}, mismatch.Path)
+}
+
+func TestCreateDecoder_ReturnsInvalidSizeError(t *testing.T) {
+	_, err := CreateDecoder(9, 2, binary.BigEndian)
+
+	var invalidSize *InvalidSizeError
+	require.True(t, errors.As(err, &invalidSize))
+	require.Equal(t, fieldTag, invalidSize.Field)
+	require.Equal(t, uint8(9), invalidSize.Size)
+}
+
+func TestSchemaError(t *testing.T) {
+	schema := MustCreateSchema(TagDefinition{Tag: 0x0001, Name: "message"}, TagDefinition{Tag: 0x0102, Name: "title"})
+	expected := "schema violation for tag 0x102 (title), length 0 is below the minimum of 1 " +
+		"at offset 8 under message/0x101"
+
+	err := &SchemaError{Offset: 8, Tag: 0x0102, Reason: "length 0 is below the minimum of 1", Path: []Tag{0x1, 0x101}}
+	err.schema = schema
+
+	require.Equal(t, expected, err.Error())
+	require.True(t, errors.Is(err, ErrSchemaViolation))
+}
+
+func TestDuplicateDefinitionError(t *testing.T) {
+	err := &DuplicateDefinitionError{Tag: 0x0102}
+
+	require.Equal(t, "tag 0x102 is already defined in the schema", err.Error())
+	require.True(t, errors.Is(err, ErrDuplicateTag))
+}
+
+func TestPartialDecodeError(t *testing.T) {
+	err := &PartialDecodeError{Decoded: 2, Failures: []DecodeFailure{{Reason: "message is too short"}}}
+
+	require.Equal(t, "partial decode, 2 nodes decoded and 1 failed, first failure: message is too short", err.Error())
+	require.True(t, errors.Is(err, ErrPartialDecode))
+}
+
+func TestLimitErrors(t *testing.T) {
+	scenarios := map[string]error{
+		"value length 9 for tag 0x102 exceeds the limit of 8 bytes at offset 4 under 0x1": &ValueTooLongError{
+			Offset: 4, Tag: 0x0102, Length: 9, Max: 8, Path: []Tag{0x1},
+		},
+		"too many nodes, the limit of 2 nodes was reached at offset 12": &TooManyNodesError{Offset: 12, Max: 2},
+		"nesting depth 3 exceeds the limit of 2 at offset 8 under 0x1/0x101": &TooDeepError{
+			Offset: 8, Depth: 3, Max: 2, Path: []Tag{0x1, 0x101},
+		},
+		"reader data exceeds the limit of 10 bytes": &ReaderTooLargeError{Max: 10},
+	}
+
+	for expected, err := range scenarios {
+		require.Equal(t, expected, err.Error())
+	}
+
+	require.True(t, errors.Is(&ValueTooLongError{}, ErrValueTooLong))
+	require.True(t, errors.Is(&TooManyNodesError{}, ErrTooManyNodes))
+	require.True(t, errors.Is(&TooDeepError{}, ErrTooDeep))
+	require.True(t, errors.Is(&ReaderTooLargeError{}, ErrReaderTooLarge))
+}
+
+func TestStrictErrors(t *testing.T) {
+	scenarios := map[string]error{
+		"2 bytes of trailing data after the last node at offset 75": &TrailingDataError{Offset: 75, Size: 2},
+		"empty value for tag 0x3 at offset 9":                       &EmptyValueError{Offset: 9, Tag: 0x03},
+		"duplicate tag 0x101, first found at offset 4, repeated at offset 39 under 0x1": &DuplicateNodeError{
+			Offset: 39, FirstOffset: 4, Tag: 0x0101, Path: []Tag{0x1},
+		},
+		"zero tag at offset 0": &ZeroTagError{},
+	}
+
+	for expected, err := range scenarios {
+		require.Equal(t, expected, err.Error())
+	}
+
+	require.True(t, errors.Is(&TrailingDataError{}, ErrTrailingData))
+	require.True(t, errors.Is(&EmptyValueError{}, ErrEmptyValue))
+	require.True(t, errors.Is(&DuplicateNodeError{}, ErrDuplicateTag))
+	require.True(t, errors.Is(&ZeroTagError{}, ErrZeroTag))
+}
+
+func TestUnbalancedBuilderError(t *testing.T) {
+	require.Equal(t, "unbalanced builder, End called without a matching Begin", (&UnbalancedBuilderError{}).Error())
+	require.Equal(
+		t, "unbalanced builder, nodes not ended: message/push_notification",
+		(&UnbalancedBuilderError{Open: []Tag{tagMessage, 0x101}, schema: testSchema}).Error(),
+	)
+	require.True(t, errors.Is(&UnbalancedBuilderError{}, ErrUnbalancedBuilder))
+}
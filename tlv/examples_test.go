@@ -41,6 +41,33 @@ func ExampleDecodeSingle() {
 	// {Title:You there? Silent:true ActionID:240 Timestamp:2021-03-01 04:02:03 +0000 UTC}
 }
 
+func ExampleUnmarshal() {
+	var message struct {
+		PushNotifications []struct {
+			Title     string    `tlv:"0x0102"`
+			Silent    bool      `tlv:"0x0105"`
+			ActionID  uint64    `tlv:"0x0103"`
+			Timestamp time.Time `tlv:"0x0104"`
+		} `tlv:"0x0101"`
+	}
+
+	items, _, err := DecodeSingle(data)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = Unmarshal(items.Value, &message); err != nil {
+		panic(err)
+	}
+
+	for _, pn := range message.PushNotifications {
+		fmt.Printf("%+v\n", pn)
+	}
+
+	// Output: {Title:Hello there! Silent:false ActionID:12345678 Timestamp:2021-06-30 15:34:56 +0000 UTC}
+	// {Title:You there? Silent:true ActionID:240 Timestamp:2021-03-01 04:02:03 +0000 UTC}
+}
+
 const (
 	tagMessage          Tag = 0x0001
 	tagPushNotification Tag = 0x0101
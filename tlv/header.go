@@ -0,0 +1,70 @@
+package tlv
+
+import (
+	"errors"
+
+	"github.com/pauloavelar/go-tlv/tlv/internal/utils"
+)
+
+// errIncompleteHeader is returned by header formats when the data ends before the header does.
+var errIncompleteHeader = errors.New("incomplete header")
+
+// headerFormat reads and writes the tag and length that precede every TLV value.
+type headerFormat interface {
+	// read parses the header at the start of data and returns its size in bytes.
+	read(data []byte) (tag Tag, length uint64, size int, err error)
+	// append writes the header of a node with the given tag and value length.
+	append(dst []byte, tag Tag, length uint64) ([]byte, error)
+	// size returns how many bytes the header of a node with the given tag and value length takes.
+	size(tag Tag, length uint64) int
+	// minSize returns the smallest possible header size.
+	minSize() int
+	// isConstructed returns whether the tag marks the value as nested TLV.
+	isConstructed(tag Tag) bool
+}
+
+// fixedHeader uses the same amount of bytes for every tag and length.
+type fixedHeader struct {
+	tagSize      uint8
+	lengthSize   uint8
+	littleEndian bool
+}
+
+func (h *fixedHeader) read(data []byte) (tag Tag, length uint64, size int, err error) {
+	size = h.minSize()
+	if len(data) < size {
+		return 0, 0, 0, errIncompleteHeader
+	}
+
+	tag = Tag(utils.ReadUint(data[:h.tagSize], h.littleEndian))
+	length = utils.ReadUint(data[h.tagSize:size], h.littleEndian)
+
+	return tag, length, size, nil
+}
+
+func (h *fixedHeader) append(dst []byte, tag Tag, length uint64) ([]byte, error) {
+	start := len(dst)
+	dst = append(dst, make([]byte, h.minSize())...)
+
+	if !utils.WriteUint(dst[start:start+int(h.tagSize)], uint64(tag), h.littleEndian) {
+		return nil, &OverflowError{Field: fieldTag, Value: uint64(tag), Size: h.tagSize}
+	}
+
+	if !utils.WriteUint(dst[start+int(h.tagSize):], length, h.littleEndian) {
+		return nil, &OverflowError{Field: fieldLength, Value: length, Size: h.lengthSize}
+	}
+
+	return dst, nil
+}
+
+func (h *fixedHeader) size(_ Tag, _ uint64) int {
+	return h.minSize()
+}
+
+func (h *fixedHeader) minSize() int {
+	return int(h.tagSize) + int(h.lengthSize)
+}
+
+func (*fixedHeader) isConstructed(_ Tag) bool {
+	return false
+}
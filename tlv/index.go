@@ -0,0 +1,137 @@
+package tlv
+
+import "sync"
+
+// IndexedNodes is a read-only view of [Nodes] with a tag index, so lookups take constant time
+// instead of scanning all nodes. The index is built on the first lookup and keeps the original
+// order of repeated tags. It is safe for concurrent use.
+type IndexedNodes struct {
+	nodes     Nodes
+	once      sync.Once
+	positions map[Tag][]int
+}
+
+// childIndex caches the indexed nested nodes of a [Node] decoded with [WithTagIndex].
+// It is shared by all copies of the node and only allocated for values that may be nested.
+type childIndex struct {
+	once  sync.Once
+	nodes *IndexedNodes
+	err   error
+}
+
+// NewIndexedNodes creates an [IndexedNodes] view of the nodes, which must not be changed while in use.
+func NewIndexedNodes(nodes Nodes) *IndexedNodes {
+	return &IndexedNodes{nodes: nodes}
+}
+
+// WithTagIndex makes nodes cache the indexed view of their children, so calling [Node.GetIndexedNodes]
+// on any copy of a node decodes and indexes its value only once. Empty values and tags defined by the
+// schema as something other than nested are leaves, so they are not cached.
+func WithTagIndex() Option {
+	return func(c *decoderConfig) {
+		c.tagIndex = true
+	}
+}
+
+// DecodeIndexed decodes a byte array as TLV [Nodes] wrapped in an [IndexedNodes] view.
+func (d *decoder) DecodeIndexed(data []byte) (*IndexedNodes, error) {
+	nodes, err := d.DecodeBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIndexedNodes(nodes), nil
+}
+
+// GetIndexedNodes parses the value as TLV nodes wrapped in an [IndexedNodes] view.
+// When the decoder was created with [WithTagIndex] and the value is not a leaf, the result is cached and
+// shared by all copies of the node.
+func (n *Node) GetIndexedNodes() (*IndexedNodes, error) {
+	if n.index == nil {
+		return n.decodeIndexed()
+	}
+
+	n.index.once.Do(func() {
+		n.index.nodes, n.index.err = n.decodeIndexed()
+	})
+
+	return n.index.nodes, n.index.err
+}
+
+// newChildIndex returns an empty cache for a node with the tag and value, or nil if it is not cached.
+func (d *decoder) newChildIndex(tag Tag, value []byte) *childIndex {
+	if !d.tagIndex || len(value) == 0 {
+		return nil
+	}
+
+	if _, defined := d.schema.Lookup(tag); defined && !d.isNested(tag) {
+		return nil
+	}
+
+	return &childIndex{}
+}
+
+func (n *Node) decodeIndexed() (*IndexedNodes, error) {
+	nodes, err := n.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIndexedNodes(nodes), nil
+}
+
+// Nodes returns the nodes in their original order.
+func (in *IndexedNodes) Nodes() Nodes {
+	return in.nodes
+}
+
+// Len returns the number of nodes.
+func (in *IndexedNodes) Len() int {
+	return len(in.nodes)
+}
+
+// GetByTag returns nodes that match the tag, in their original order.
+func (in *IndexedNodes) GetByTag(tag Tag) Nodes {
+	positions := in.GetPositions(tag)
+	if len(positions) == 0 {
+		return nil
+	}
+
+	res := make(Nodes, len(positions))
+	for i, position := range positions {
+		res[i] = in.nodes[position]
+	}
+
+	return res
+}
+
+// GetFirstByTag returns the first node that matches the tag.
+func (in *IndexedNodes) GetFirstByTag(tag Tag) (res Node, ok bool) {
+	positions := in.GetPositions(tag)
+	if len(positions) == 0 {
+		return res, false
+	}
+
+	return in.nodes[positions[0]], true
+}
+
+// HasTag returns if a tag is present in the nodes.
+func (in *IndexedNodes) HasTag(tag Tag) bool {
+	return len(in.GetPositions(tag)) > 0
+}
+
+// GetPositions returns the positions of the nodes that match the tag, in ascending order.
+// The result must not be modified.
+func (in *IndexedNodes) GetPositions(tag Tag) []int {
+	in.once.Do(in.buildIndex)
+
+	return in.positions[tag]
+}
+
+func (in *IndexedNodes) buildIndex() {
+	in.positions = make(map[Tag][]int)
+
+	for i := range in.nodes {
+		in.positions[in.nodes[i].Tag] = append(in.positions[in.nodes[i].Tag], i)
+	}
+}
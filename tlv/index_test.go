@@ -0,0 +1,145 @@
+package tlv
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexedNodes(t *testing.T) {
+	nodes := Nodes{NewNode(0x01, []byte{1}), NewNode(0x02, nil), NewNode(0x01, []byte{2}), NewNode(0x03, nil)}
+
+	indexed := NewIndexedNodes(nodes)
+
+	require.Equal(t, nodes, indexed.Nodes())
+	require.Equal(t, 4, indexed.Len())
+	require.Equal(t, nodes.GetByTag(0x01), indexed.GetByTag(0x01))
+	require.Equal(t, []int{0, 2}, indexed.GetPositions(0x01))
+	require.True(t, indexed.HasTag(0x03))
+
+	first, ok := indexed.GetFirstByTag(0x02)
+	require.True(t, ok)
+	require.Equal(t, nodes[1], first)
+}
+
+func TestIndexedNodes_WhenTheTagIsMissing(t *testing.T) {
+	indexed := NewIndexedNodes(Nodes{NewNode(0x01, nil)})
+
+	_, ok := indexed.GetFirstByTag(0x02)
+
+	require.False(t, ok)
+	require.False(t, indexed.HasTag(0x02))
+	require.Nil(t, indexed.GetByTag(0x02))
+}
+
+func TestIndexedNodes_WhenUsedConcurrently(t *testing.T) {
+	indexed := NewIndexedNodes(Nodes{NewNode(0x01, nil), NewNode(0x02, nil)})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.True(t, indexed.HasTag(0x02))
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestDecoder_DecodeIndexed(t *testing.T) {
+	indexed, err := DecodeIndexed(data)
+	require.Nil(t, err)
+
+	message, ok := indexed.GetFirstByTag(tagMessage)
+	require.True(t, ok)
+
+	items, err := message.GetIndexedNodes()
+	require.Nil(t, err)
+	require.Len(t, items.GetByTag(tagPushNotification), 2)
+
+	_, err = DecodeIndexed(data[:3])
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+}
+
+func TestNode_GetIndexedNodes_WithTagIndex(t *testing.T) {
+	nodes, err := MustCreateDecoderWithOptions(WithTagIndex()).DecodeBytes(data)
+	require.Nil(t, err)
+
+	first, err := nodes[0].GetIndexedNodes()
+	require.Nil(t, err)
+
+	message := nodes[0]
+	second, err := message.GetIndexedNodes()
+	require.Nil(t, err)
+	require.Same(t, first, second)
+
+	withoutIndex, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	third, err := withoutIndex[0].GetIndexedNodes()
+	require.Nil(t, err)
+
+	fourth, err := withoutIndex[0].GetIndexedNodes()
+	require.Nil(t, err)
+	require.NotSame(t, third, fourth)
+}
+
+func TestNode_GetIndexedNodes_WithTagIndexOnLeaves(t *testing.T) {
+	d := MustCreateDecoderWithOptions(WithTagIndex(), WithSchema(testSchema))
+
+	nodes, err := d.DecodeBytes(data)
+	require.Nil(t, err)
+
+	items, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	children, err := items[0].GetNodes()
+	require.Nil(t, err)
+	require.NotNil(t, items[0].index)
+	require.Nil(t, children[0].index)
+
+	withoutSchema, err := MustCreateDecoderWithOptions(WithTagIndex()).DecodeBytes(data)
+	require.Nil(t, err)
+	require.NotNil(t, withoutSchema[0].index)
+
+	empty := MustCreateDecoderWithOptions(WithTagIndex()).NewNode(tagMessage, nil)
+	require.Nil(t, empty.index)
+}
+
+func TestNode_GetIndexedNodes_WhenTheValueIsInvalid(t *testing.T) {
+	nodes, err := MustCreateDecoderWithOptions(WithTagIndex(), WithSchema(testSchema)).DecodeBytes(
+		[]byte{0x00, 0x01, 0x00, 0x01, 0xff},
+	)
+	require.Nil(t, err)
+
+	_, err = nodes[0].GetIndexedNodes()
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+
+	_, err = nodes[0].GetIndexedNodes()
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+}
+
+func BenchmarkNodes_GetFirstByTag(b *testing.B) {
+	nodes, err := DecodeBytes(newTelemetryData(500))
+	require.Nil(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		nodes.GetFirstByTag(Tag(i % 256))
+	}
+}
+
+func BenchmarkIndexedNodes_GetFirstByTag(b *testing.B) {
+	nodes, err := DecodeBytes(newTelemetryData(500))
+	require.Nil(b, err)
+
+	indexed := NewIndexedNodes(nodes)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		indexed.GetFirstByTag(Tag(i % 256))
+	}
+}
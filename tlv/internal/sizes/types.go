@@ -1,9 +1,15 @@
 package sizes
 
 const (
-	Bool   = 1
-	Uint8  = 1
-	Uint16 = 2
-	Uint32 = 4
-	Uint64 = 8
+	Bool    = 1
+	Uint8   = 1
+	Uint16  = 2
+	Uint32  = 4
+	Uint64  = 8
+	Int8    = 1
+	Int16   = 2
+	Int32   = 4
+	Int64   = 8
+	Float32 = 4
+	Float64 = 8
 )
@@ -6,3 +6,34 @@ func MaxInt(a, b int) int {
 	}
 	return b
 }
+
+// ByteCount returns the minimum amount of bytes needed to represent the value (at least 1).
+func ByteCount(value uint64) int {
+	count := 1
+	for value > 0xff {
+		value >>= 8
+		count++
+	}
+	return count
+}
+
+// SignExtend interprets the lowest bits of the value as a two's complement signed integer.
+func SignExtend(value uint64, bits int) int64 {
+	if bits <= 0 {
+		return 0
+	}
+
+	if bits >= 64 {
+		return int64(value)
+	}
+
+	shift := uint(64 - bits)
+	return int64(value<<shift) >> shift
+}
+
+func MinInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -10,3 +10,23 @@ func TestMaxInt(t *testing.T) {
 	require.Equal(t, 9, MaxInt(1, 9))
 	require.Equal(t, 8, MaxInt(8, 2))
 }
+
+func TestMinInt(t *testing.T) {
+	require.Equal(t, 1, MinInt(1, 9))
+	require.Equal(t, 2, MinInt(8, 2))
+}
+
+func TestByteCount(t *testing.T) {
+	require.Equal(t, 1, ByteCount(0))
+	require.Equal(t, 1, ByteCount(0xff))
+	require.Equal(t, 2, ByteCount(0x100))
+	require.Equal(t, 8, ByteCount(1<<63))
+}
+
+func TestSignExtend(t *testing.T) {
+	require.Equal(t, int64(-1), SignExtend(0xff, 8))
+	require.Equal(t, int64(127), SignExtend(0x7f, 8))
+	require.Equal(t, int64(-2), SignExtend(0xfffe, 16))
+	require.Equal(t, int64(-1), SignExtend(0xffffffffffffffff, 64))
+	require.Equal(t, int64(0), SignExtend(0xff, 0))
+}
@@ -6,12 +6,68 @@ import (
 	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
 )
 
-func GetPadding(typeSize, valueSize int) []byte {
-	padSize := MaxInt(0, typeSize-valueSize)
-	return make([]byte, padSize, typeSize)
-}
+const bitsPerByte = 8
+
+// littleEndianProbe is used to detect the byte order of custom implementations.
+var littleEndianProbe = []byte{0x01, 0x00}
 
+// GetPaddedUint64 parses up to the first 8 bytes of data as an unsigned integer in the byte order,
+// as if shorter data was padded with zeros in its most significant positions.
 func GetPaddedUint64(byteOrder binary.ByteOrder, data []byte) uint64 {
-	padding := GetPadding(sizes.Uint64, len(data))
-	return byteOrder.Uint64(append(padding, data...))
+	return ReadUint(data, IsLittleEndian(byteOrder))
+}
+
+// ReadUint parses up to the first 8 bytes of data as an unsigned integer without allocating,
+// with the least significant byte first if littleEndian is set or last otherwise.
+func ReadUint(data []byte, littleEndian bool) uint64 {
+	if len(data) > sizes.Uint64 {
+		data = data[:sizes.Uint64]
+	}
+
+	var res uint64
+	if littleEndian {
+		for i := len(data) - 1; i >= 0; i-- {
+			res = res<<bitsPerByte | uint64(data[i])
+		}
+		return res
+	}
+
+	for _, b := range data {
+		res = res<<bitsPerByte | uint64(b)
+	}
+	return res
+}
+
+// PutPaddedUint64 writes the value in as many bytes as dst holds, discarding the padding
+// GetPaddedUint64 would add. It returns false if the value does not fit.
+func PutPaddedUint64(byteOrder binary.ByteOrder, dst []byte, value uint64) bool {
+	return WriteUint(dst, value, IsLittleEndian(byteOrder))
+}
+
+// WriteUint is the inverse of ReadUint, writing the value in up to the first 8 bytes of dst
+// without allocating. It returns false if the value does not fit.
+func WriteUint(dst []byte, value uint64, littleEndian bool) bool {
+	if len(dst) > sizes.Uint64 {
+		dst = dst[:sizes.Uint64]
+	}
+
+	if len(dst) < sizes.Uint64 && value>>(bitsPerByte*len(dst)) != 0 {
+		return false
+	}
+
+	for i := range dst {
+		b := byte(value >> (bitsPerByte * i))
+		if littleEndian {
+			dst[i] = b
+		} else {
+			dst[len(dst)-1-i] = b
+		}
+	}
+
+	return true
+}
+
+// IsLittleEndian returns whether the least significant bytes come first in the byte order.
+func IsLittleEndian(byteOrder binary.ByteOrder) bool {
+	return byteOrder.Uint16(littleEndianProbe) == 0x01
 }
@@ -7,14 +7,71 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestGetPadding(t *testing.T) {
-	padding := GetPadding(4, 2)
+func TestGetPaddedUint64(t *testing.T) {
+	value := GetPaddedUint64(binary.BigEndian, []byte{0x01, 0x23, 0x45})
 
-	require.Equal(t, []byte{0, 0}, padding)
+	require.EqualValues(t, 0x12345, value)
 }
 
-func TestGetPaddedUint64(t *testing.T) {
-	value := GetPaddedUint64(binary.BigEndian, []byte{0x01, 0x23, 0x45})
+func TestGetPaddedUint64_WhenTheByteOrderIsLittleEndian(t *testing.T) {
+	value := GetPaddedUint64(binary.LittleEndian, []byte{0x45, 0x23, 0x01})
 
 	require.EqualValues(t, 0x12345, value)
 }
+
+func TestPutPaddedUint64(t *testing.T) {
+	scenarios := map[binary.ByteOrder][]byte{
+		binary.BigEndian:    {0x01, 0x23, 0x45},
+		binary.LittleEndian: {0x45, 0x23, 0x01},
+	}
+
+	for byteOrder, expected := range scenarios {
+		dst := make([]byte, 3)
+
+		ok := PutPaddedUint64(byteOrder, dst, 0x12345)
+
+		require.True(t, ok)
+		require.Equal(t, expected, dst)
+	}
+}
+
+func TestPutPaddedUint64_WhenTheValueDoesNotFit(t *testing.T) {
+	ok := PutPaddedUint64(binary.BigEndian, make([]byte, 2), 0x12345)
+
+	require.False(t, ok)
+}
+
+func TestReadUint(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09}
+
+	require.EqualValues(t, 0x010203, ReadUint(data[:3], false))
+	require.EqualValues(t, 0x030201, ReadUint(data[:3], true))
+	require.EqualValues(t, uint64(0x0102030405060708), ReadUint(data, false))
+	require.EqualValues(t, uint64(0x0807060504030201), ReadUint(data, true))
+	require.Zero(t, ReadUint(nil, false))
+}
+
+func TestWriteUint(t *testing.T) {
+	dst := make([]byte, 9)
+
+	require.True(t, WriteUint(dst, 0x0102030405060708, false))
+	require.Equal(t, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x00}, dst)
+	require.True(t, WriteUint(dst[:2], 0x0102, true))
+	require.Equal(t, []byte{0x02, 0x01}, dst[:2])
+	require.False(t, WriteUint(dst[:1], 0x0102, true))
+}
+
+func TestReadUint_DoesNotAllocate(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+
+	for size := 1; size <= len(data); size++ {
+		for _, byteOrder := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			allocs := testing.AllocsPerRun(100, func() {
+				value := GetPaddedUint64(byteOrder, data[:size])
+				PutPaddedUint64(byteOrder, data[:size], value)
+			})
+
+			require.Zero(t, allocs)
+		}
+	}
+}
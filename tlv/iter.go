@@ -0,0 +1,91 @@
+package tlv
+
+// The iterators below have the same types as iter.Seq and iter.Seq2 from the standard library,
+// so they can be used with range-over-func loops (Go 1.23+) or called with a yield function.
+
+// All returns an iterator that decodes the nodes in data one at a time, without building a list
+// of [Nodes]. Limits, strict modes and the schema are enforced for each node. Decoding errors are
+// yielded once with an empty [Node] and end the iteration. Empty data yields no nodes.
+// With [WithCopiedValues], data is copied once when the iteration starts.
+func (d *decoder) All(data []byte) func(yield func(Node, error) bool) {
+	return func(yield func(Node, error) bool) {
+		if d.copyValues {
+			d.yieldAll(append([]byte(nil), data...), yield)
+		} else {
+			d.yieldAll(data, yield)
+		}
+	}
+}
+
+// rootIteration is the state kept by All between root nodes.
+type rootIteration struct {
+	checker strictChecker
+	decoded int        // Root nodes decoded so far.
+	count   *nodeCount // Nodes decoded so far, nested ones included.
+}
+
+func (d *decoder) yieldAll(data []byte, yield func(Node, error) bool) {
+	it := rootIteration{checker: d.newStrictChecker(), count: d.newNodeCount()}
+
+	for offset := uint64(0); offset < uint64(len(data)); it.decoded++ {
+		node, err := d.decodeNext(data[offset:], offset, &it)
+		if err != nil {
+			yield(Node{}, err)
+			return
+		}
+
+		if !yield(node, nil) {
+			return
+		}
+
+		offset += uint64(len(node.Raw))
+	}
+}
+
+// decodeNext decodes the next root node, which shares the count of decoded nodes with the other ones.
+func (d *decoder) decodeNext(data []byte, offset uint64, it *rootIteration) (Node, error) {
+	node, _, err := d.decodeSingle(data, offset, nil)
+	if err != nil {
+		return Node{}, it.checker.checkTrailing(err, it.decoded, uint64(len(data)))
+	}
+
+	if err = d.countNode(it.count, offset, nil); err != nil {
+		return Node{}, err
+	}
+
+	node.count = it.count
+
+	if err = it.checker.check(&node); err != nil {
+		return Node{}, err
+	}
+
+	if err = d.validate(Nodes{node}, nil); err != nil {
+		return Node{}, err
+	}
+
+	return node, nil
+}
+
+// ByTag returns an iterator over the nodes that match the tag, without allocating a new list.
+func (ns Nodes) ByTag(tag Tag) func(yield func(Node) bool) {
+	return func(yield func(Node) bool) {
+		for i := range ns {
+			if ns[i].Tag == tag && !yield(ns[i]) {
+				return
+			}
+		}
+	}
+}
+
+// DepthFirst returns an iterator over the nodes and their nested nodes in the order they are
+// visited by [Walk], decoding nested values only as the iteration reaches them.
+func (ns Nodes) DepthFirst() func(yield func(Node) bool) {
+	return func(yield func(Node) bool) {
+		_ = Walk(ns, func(node *Node, _ []Tag, _ int) error {
+			if !yield(*node) {
+				return SkipAll
+			}
+			return nil
+		})
+	}
+}
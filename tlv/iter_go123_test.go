@@ -0,0 +1,43 @@
+//go:build go1.23
+
+package tlv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_All_WithRangeOverFunc(t *testing.T) {
+	count := 0
+
+	for node, err := range All(newTelemetryData(5)) {
+		require.Nil(t, err)
+		require.Equal(t, Tag(count), node.Tag)
+
+		if count++; count == 3 {
+			break
+		}
+	}
+
+	require.Equal(t, 3, count)
+}
+
+func TestNodes_ByTag_WithRangeOverFunc(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	var titles []string
+	for node := range nodes.DepthFirst() {
+		if node.Tag == tagPushNotification {
+			children, err := node.GetNodes()
+			require.Nil(t, err)
+
+			for title := range children.ByTag(tagTitle) {
+				titles = append(titles, title.GetString())
+			}
+		}
+	}
+
+	require.Equal(t, []string{"Hello there!", "You there?"}, titles)
+}
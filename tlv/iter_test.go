@@ -0,0 +1,103 @@
+package tlv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_All(t *testing.T) {
+	var tags []Tag
+	var offsets []uint64
+
+	All(newTelemetryData(3))(func(node Node, err error) bool {
+		require.Nil(t, err)
+		tags = append(tags, node.Tag)
+		offsets = append(offsets, node.offset)
+		return true
+	})
+
+	require.Equal(t, []Tag{0x00, 0x01, 0x02}, tags)
+	require.Equal(t, []uint64{0, 6, 12}, offsets)
+}
+
+func TestDecoder_All_WhenTheIterationStopsEarly(t *testing.T) {
+	calls := 0
+
+	All(newTelemetryData(3))(func(node Node, err error) bool {
+		calls++
+		return false
+	})
+
+	require.Equal(t, 1, calls)
+}
+
+func TestDecoder_All_WhenTheDataIsCorrupted(t *testing.T) {
+	var errs []error
+
+	All(corruptedData)(func(node Node, err error) bool {
+		errs = append(errs, err)
+		return true
+	})
+
+	require.Len(t, errs, 2)
+	require.Nil(t, errs[0])
+	require.True(t, errors.Is(errs[1], ErrLengthMismatch))
+}
+
+func TestDecoder_All_WithLimitsAndStrictModes(t *testing.T) {
+	scenarios := map[error]Decoder{
+		ErrTooManyNodes:    stdDecoder.WithLimits(Limits{MaxNodes: 2}),
+		ErrZeroTag:         MustCreateDecoderWithOptions(WithStrictZeroTags()),
+		ErrSchemaViolation: stdDecoder.WithSchema(MustCreateSchema(TagDefinition{Tag: 0x01, MaxLength: 1})),
+		ErrTrailingData:    MustCreateDecoderWithOptions(WithStrictTrailingData()),
+	}
+
+	input := append(newTelemetryData(3), 0x00)
+	for expected, d := range scenarios {
+		var last error
+		d.All(input)(func(node Node, err error) bool {
+			last = err
+			return true
+		})
+
+		require.True(t, errors.Is(last, expected), expected.Error())
+	}
+}
+
+func TestDecoder_All_WhenTheDataIsEmpty(t *testing.T) {
+	calls := 0
+
+	All(nil)(func(Node, error) bool {
+		calls++
+		return true
+	})
+
+	require.Zero(t, calls)
+}
+
+func TestNodes_ByTag(t *testing.T) {
+	nodes := Nodes{NewNode(0x01, []byte{1}), NewNode(0x02, nil), NewNode(0x01, []byte{2}), NewNode(0x01, []byte{3})}
+
+	var values []byte
+	nodes.ByTag(0x01)(func(node Node) bool {
+		values = append(values, node.Value[0])
+		return len(values) < 2
+	})
+
+	require.Equal(t, []byte{1, 2}, values)
+}
+
+func TestNodes_DepthFirst(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	var tags []Tag
+	nodes.DepthFirst()(func(node Node) bool {
+		tags = append(tags, node.Tag)
+		return node.Tag != tagTimestamp
+	})
+
+	require.Equal(t, []Tag{tagMessage, tagPushNotification, tagTitle, tagActionID, tagTimestamp}, tags)
+}
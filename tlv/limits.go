@@ -0,0 +1,140 @@
+package tlv
+
+import (
+	"io"
+	"math"
+	"sync/atomic"
+)
+
+// Limits protects decoding against hostile input. Zero values mean unlimited.
+type Limits struct {
+	MaxValueLength uint64 // Largest value length accepted in node headers.
+	MaxNodes       int    // Largest number of nodes decoded from one buffer or Scanner, including later GetNodes calls.
+	MaxDepth       int    // Deepest nesting level decoded, root nodes being at level 1.
+	MaxReaderSize  int64  // Largest amount of bytes read by DecodeReader or a Scanner.
+}
+
+// WithLimits returns a copy of the [Decoder] that enforces the [Limits] when decoding.
+func (d *decoder) WithLimits(limits Limits) Decoder {
+	res := *d
+	res.limits = limits
+
+	return &res
+}
+
+// GetLimits returns the [Decoder] limits.
+func (d *decoder) GetLimits() Limits {
+	return d.limits
+}
+
+// readAll reads the whole reader, failing if it holds more than MaxReaderSize bytes.
+func (d *decoder) readAll(reader io.Reader) ([]byte, error) {
+	limit := d.limits.MaxReaderSize
+	if limit <= 0 || limit == math.MaxInt64 {
+		return io.ReadAll(reader)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, &ReaderTooLargeError{Max: limit}
+	}
+
+	return data, nil
+}
+
+func (d *decoder) checkValueLength(tag Tag, length, offset uint64, path []Tag) error {
+	if d.limits.MaxValueLength == 0 || length <= d.limits.MaxValueLength {
+		return nil
+	}
+
+	return &ValueTooLongError{
+		Offset: offset,
+		Tag:    tag,
+		Length: Length(length),
+		Max:    d.limits.MaxValueLength,
+		Path:   copyPath(path),
+		schema: d.schema,
+	}
+}
+
+// nodeCount is the number of nodes decoded from the same root decoding, shared by all its nodes
+// so nested values decoded later are counted too.
+type nodeCount struct {
+	decoded int64
+}
+
+// newNodeCount returns a count for a new root decoding, or nil when the number of nodes is unlimited.
+func (d *decoder) newNodeCount() *nodeCount {
+	if d.limits.MaxNodes <= 0 {
+		return nil
+	}
+
+	return &nodeCount{}
+}
+
+// countNode adds one more node to the count, failing without adding it if it would exceed MaxNodes.
+func (d *decoder) countNode(count *nodeCount, offset uint64, path []Tag) error {
+	if count == nil {
+		return nil
+	}
+
+	if err := d.checkNodeCount(int(atomic.AddInt64(&count.decoded, 1)-1), offset, path); err != nil {
+		count.release(1)
+		return err
+	}
+
+	return nil
+}
+
+// release removes nodes that were counted but then discarded because their decoding failed.
+func (c *nodeCount) release(nodes int) {
+	if c != nil && nodes > 0 {
+		atomic.AddInt64(&c.decoded, -int64(nodes))
+	}
+}
+
+// getDecoded returns how many nodes were counted.
+func (c *nodeCount) getDecoded() int {
+	if c == nil {
+		return 0
+	}
+
+	return int(atomic.LoadInt64(&c.decoded))
+}
+
+// checkNodeCount fails if decoding one more node would exceed MaxNodes.
+func (d *decoder) checkNodeCount(decoded int, offset uint64, path []Tag) error {
+	if d.limits.MaxNodes <= 0 || decoded < d.limits.MaxNodes {
+		return nil
+	}
+
+	return &TooManyNodesError{Offset: offset, Max: d.limits.MaxNodes, Path: copyPath(path), schema: d.schema}
+}
+
+// checkDepth fails if nodes under the path are deeper than MaxDepth.
+func (d *decoder) checkDepth(offset uint64, path []Tag) error {
+	depth := len(path) + 1
+	if d.limits.MaxDepth <= 0 || depth <= d.limits.MaxDepth {
+		return nil
+	}
+
+	return &TooDeepError{Offset: offset, Depth: depth, Max: d.limits.MaxDepth, Path: copyPath(path), schema: d.schema}
+}
+
+// checkReaderSize fails if reading size more bytes after the used ones would exceed MaxReaderSize.
+func (d *decoder) checkReaderSize(used, size uint64) error {
+	if d.limits.MaxReaderSize <= 0 {
+		return nil
+	}
+
+	limit := uint64(d.limits.MaxReaderSize)
+	if used > limit || size > limit-used {
+		return &ReaderTooLargeError{Max: d.limits.MaxReaderSize}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package tlv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_WithLimits(t *testing.T) {
+	limits := Limits{MaxValueLength: 1, MaxNodes: 2, MaxDepth: 3, MaxReaderSize: 4}
+
+	d := stdDecoder.WithLimits(limits)
+
+	require.Equal(t, limits, d.GetLimits())
+	require.Equal(t, Limits{}, stdDecoder.GetLimits())
+}
+
+func TestDecoder_DecodeBytes_WhenAValueIsTooLong(t *testing.T) {
+	_, err := stdDecoder.WithLimits(Limits{MaxValueLength: 70}).DecodeBytes(data)
+
+	var limitErr *ValueTooLongError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, tagMessage, limitErr.Tag)
+	require.Equal(t, Length(71), limitErr.Length)
+	require.Zero(t, limitErr.Offset)
+}
+
+func TestDecoder_DecodeBytes_WhenTheLengthTakesEightBytes(t *testing.T) {
+	input := []byte{0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}
+	d := MustCreateDecoder(1, 8, binary.BigEndian)
+
+	_, err := d.DecodeBytes(input)
+	require.True(t, errors.Is(err, ErrLengthMismatch))
+
+	_, err = d.WithLimits(Limits{MaxValueLength: math.MaxUint32}).DecodeBytes(input)
+	require.True(t, errors.Is(err, ErrValueTooLong))
+}
+
+func TestDecoder_DecodeBytes_WhenThereAreTooManyNodes(t *testing.T) {
+	_, err := stdDecoder.WithLimits(Limits{MaxNodes: 5}).DecodeBytes(newTelemetryData(10))
+
+	var limitErr *TooManyNodesError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, uint64(30), limitErr.Offset)
+	require.Equal(t, 5, limitErr.Max)
+
+	nodes, err := stdDecoder.WithLimits(Limits{MaxNodes: 10}).DecodeBytes(newTelemetryData(10))
+	require.Nil(t, err)
+	require.Len(t, nodes, 10)
+}
+
+func TestNode_GetNodes_WhenTheTreeHasTooManyNodes(t *testing.T) {
+	nodes, err := stdDecoder.WithLimits(Limits{MaxNodes: 9}).DecodeBytes(data)
+	require.Nil(t, err)
+
+	items, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	_, err = items[0].GetNodes()
+	require.Nil(t, err)
+
+	_, err = items[1].GetNodes()
+
+	var limitErr *TooManyNodesError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, uint64(67), limitErr.Offset)
+	require.Equal(t, 9, limitErr.Max)
+	require.Equal(t, []Tag{tagMessage, tagPushNotification}, limitErr.Path)
+}
+
+func TestWalk_WhenTheTreeHasTooManyNodes(t *testing.T) {
+	builder := NewBuilder()
+	for i := 0; i < 3; i++ {
+		builder.Begin(tagMessage).Uint8(0x02, 1).Uint8(0x03, 2).Uint8(0x04, 3).Uint8(0x05, 4).End()
+	}
+
+	input, err := builder.Bytes()
+	require.Nil(t, err)
+
+	nodes, err := stdDecoder.WithLimits(Limits{MaxNodes: 3}).DecodeBytes(input)
+	require.Nil(t, err)
+
+	err = Walk(nodes, func(*Node, []Tag, int) error { return nil })
+	require.True(t, errors.Is(err, ErrTooManyNodes))
+
+	nodes, err = stdDecoder.WithLimits(Limits{MaxNodes: 10}).DecodeBytes(data)
+	require.Nil(t, err)
+	require.Nil(t, Walk(nodes, func(*Node, []Tag, int) error { return nil }))
+}
+
+func TestNode_GetNodes_WhenTheNestingIsTooDeep(t *testing.T) {
+	nodes, err := stdDecoder.WithLimits(Limits{MaxDepth: 2}).DecodeBytes(data)
+	require.Nil(t, err)
+
+	items, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	_, err = items[0].GetNodes()
+
+	var limitErr *TooDeepError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, 3, limitErr.Depth)
+	require.Equal(t, uint64(8), limitErr.Offset)
+	require.Equal(t, []Tag{tagMessage, tagPushNotification}, limitErr.Path)
+}
+
+func TestDecoder_DecodeBytes_WhenTheSchemaNestingIsTooDeep(t *testing.T) {
+	nodes, err := stdDecoder.WithSchema(testSchema).WithLimits(Limits{MaxDepth: 2}).DecodeBytes(data)
+	require.Nil(t, err)
+
+	children, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	_, err = children[0].GetNodes()
+	require.True(t, errors.Is(err, ErrTooDeep))
+}
+
+func TestDecoder_DecodeReader_WhenTheReaderIsTooLarge(t *testing.T) {
+	_, err := stdDecoder.WithLimits(Limits{MaxReaderSize: int64(len(data) - 1)}).DecodeReader(bytes.NewReader(data))
+
+	var limitErr *ReaderTooLargeError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, int64(len(data)-1), limitErr.Max)
+
+	nodes, err := stdDecoder.WithLimits(Limits{MaxReaderSize: int64(len(data))}).DecodeReader(bytes.NewReader(data))
+	require.Nil(t, err)
+	require.Len(t, nodes, 1)
+}
+
+func TestDecoder_DecodeReader_WhenTheReaderFails(t *testing.T) {
+	_, err := stdDecoder.WithLimits(Limits{MaxReaderSize: 10}).DecodeReader(new(failingReader))
+
+	require.NotNil(t, err)
+}
+
+func TestScanner_Scan_WithLimits(t *testing.T) {
+	input := append(append([]byte{}, data...), data...)
+	scenarios := map[error]Limits{
+		ErrTooManyNodes:   {MaxNodes: 1},
+		ErrReaderTooLarge: {MaxReaderSize: int64(len(input) - 1)},
+		ErrValueTooLong:   {MaxValueLength: 70},
+	}
+
+	for expected, limits := range scenarios {
+		scanner := stdDecoder.WithLimits(limits).NewScanner(bytes.NewReader(input))
+		for scanner.Scan() {
+		}
+
+		require.True(t, errors.Is(scanner.Err(), expected))
+	}
+}
+
+func TestScanner_Scan_WhenTheStreamHasTooManyNodes(t *testing.T) {
+	input := append(append([]byte{}, data...), data...)
+	scanner := stdDecoder.WithLimits(Limits{MaxNodes: 3}).NewScanner(bytes.NewReader(input))
+
+	require.True(t, scanner.Scan())
+
+	node := scanner.Node()
+	_, err := node.GetNodes()
+	require.Nil(t, err)
+
+	require.False(t, scanner.Scan())
+
+	var limitErr *TooManyNodesError
+	require.True(t, errors.As(scanner.Err(), &limitErr))
+	require.Equal(t, uint64(len(data)), limitErr.Offset)
+}
+
+func TestScanner_Scan_ChecksLimitsBeforeReadingTheValue(t *testing.T) {
+	reader := io.MultiReader(bytes.NewReader([]byte{0x00, 0x01, 0x03, 0xe8}), new(failingReader))
+	scanner := stdDecoder.WithLimits(Limits{MaxValueLength: 10}).NewScanner(reader)
+
+	require.False(t, scanner.Scan())
+	require.True(t, errors.Is(scanner.Err(), ErrValueTooLong))
+}
+
+func TestScanner_Scan_WhenTheHeaderExceedsTheReaderSize(t *testing.T) {
+	reader := io.MultiReader(bytes.NewReader([]byte{0x1f, 0x81}), new(failingReader))
+	scanner := CreateBERDecoder().WithLimits(Limits{MaxReaderSize: 2}).NewScanner(reader)
+
+	require.False(t, scanner.Scan())
+	require.True(t, errors.Is(scanner.Err(), ErrReaderTooLarge))
+}
+
+func TestDecoder_DecodePartial_WhenThereAreTooManyNodes(t *testing.T) {
+	nodes, err := stdDecoder.WithLimits(Limits{MaxNodes: 2}).DecodePartial(newTelemetryData(3), RecoveryResync)
+
+	require.Len(t, nodes, 2)
+
+	var partialErr *PartialDecodeError
+	require.True(t, errors.As(err, &partialErr))
+	require.True(t, errors.Is(partialErr.Failures[0].Err, ErrTooManyNodes))
+}
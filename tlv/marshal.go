@@ -0,0 +1,203 @@
+package tlv
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
+	"github.com/pauloavelar/go-tlv/tlv/internal/utils"
+)
+
+const (
+	structTagKey       = "tlv"
+	structTagIgnore    = "-"
+	structTagOmitEmpty = "omitempty"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// structField describes a struct field mapped to a TLV tag through the `tlv` struct tag.
+type structField struct {
+	index     int
+	name      string
+	tag       Tag
+	omitEmpty bool
+}
+
+// Marshal encodes a struct as TLV bytes, mapping each field with a `tlv:"<tag>"` struct tag
+// to a node. Tags may be written in decimal or hexadecimal (e.g. `tlv:"0x0102,omitempty"`).
+//
+// Supported field types are bool, integers and floats (encoded with the size of the type),
+// string, []byte, [time.Time] (encoded as Unix seconds in 8 bytes), nested structs
+// (encoded as nested TLV) and pointers to them. Slices of these types produce one node per item.
+func (e *encoder) Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, &InvalidTargetError{Type: typeName(v), Expected: "a struct or a pointer to a struct"}
+	}
+
+	return e.marshalStruct(rv)
+}
+
+func (e *encoder) marshalStruct(rv reflect.Value) ([]byte, error) {
+	fields, err := getStructFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes Nodes
+	for _, field := range fields {
+		fv := rv.Field(field.index)
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if nodes, err = e.appendFieldNodes(nodes, field, fv); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.EncodeNodes(nodes)
+}
+
+func (e *encoder) appendFieldNodes(dst Nodes, field structField, fv reflect.Value) (Nodes, error) {
+	switch {
+	case isRepeated(fv.Type()):
+		for i := 0; i < fv.Len(); i++ {
+			var err error
+			if dst, err = e.appendFieldNodes(dst, field, fv.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return dst, nil
+	case fv.Kind() == reflect.Ptr:
+		if fv.IsNil() {
+			return dst, nil
+		}
+		return e.appendFieldNodes(dst, field, fv.Elem())
+	}
+
+	value, err := e.marshalValue(field, fv)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dst, Node{Tag: field.tag, Length: Length(len(value)), Value: value}), nil
+}
+
+func (e *encoder) marshalValue(field structField, fv reflect.Value) ([]byte, error) {
+	if fv.Type() == timeType {
+		epoch := fv.Interface().(time.Time).Unix()
+		return e.marshalUint(uint64(epoch), sizes.Uint64), nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Bool:
+		if fv.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return e.marshalUint(fv.Uint(), int(fv.Type().Size())), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.marshalInt(fv.Int(), int(fv.Type().Size())), nil
+	case reflect.Float32:
+		return e.marshalUint(uint64(math.Float32bits(float32(fv.Float()))), sizes.Float32), nil
+	case reflect.Float64:
+		return e.marshalUint(math.Float64bits(fv.Float()), sizes.Float64), nil
+	case reflect.String:
+		return []byte(fv.String()), nil
+	case reflect.Slice:
+		if isBytes(fv.Type()) {
+			return fv.Bytes(), nil
+		}
+	case reflect.Struct:
+		return e.marshalStruct(fv)
+	default:
+	}
+
+	return nil, &UnsupportedTypeError{Field: field.name, Type: fv.Type().String()}
+}
+
+func (e *encoder) marshalUint(value uint64, size int) []byte {
+	res := make([]byte, size)
+	utils.PutPaddedUint64(e.byteOrder, res, value)
+
+	return res
+}
+
+// marshalInt encodes the value in two's complement with the given size.
+func (e *encoder) marshalInt(value int64, size int) []byte {
+	bits := uint64(value)
+	if size < sizes.Int64 {
+		bits &= 1<<(bitsPerByte*size) - 1
+	}
+
+	return e.marshalUint(bits, size)
+}
+
+func getStructFields(t reflect.Type) ([]structField, error) {
+	var res []structField
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		value, ok := field.Tag.Lookup(structTagKey)
+		if !ok || value == structTagIgnore || field.PkgPath != "" {
+			continue
+		}
+
+		parsed, err := parseStructTag(field.Name, value)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed.index = i
+		res = append(res, parsed)
+	}
+
+	return res, nil
+}
+
+func parseStructTag(name, value string) (structField, error) {
+	parts := strings.Split(value, ",")
+
+	tag, err := strconv.ParseUint(parts[0], 0, 64)
+	if err != nil {
+		return structField{}, &StructTagError{Field: name, Tag: value}
+	}
+
+	res := structField{name: name, tag: Tag(tag)}
+	for _, option := range parts[1:] {
+		if option != structTagOmitEmpty {
+			return structField{}, &StructTagError{Field: name, Tag: value}
+		}
+		res.omitEmpty = true
+	}
+
+	return res, nil
+}
+
+// isRepeated returns whether values of the type are encoded as one node per item.
+func isRepeated(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && !isBytes(t)
+}
+
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+func typeName(v interface{}) string {
+	if v == nil {
+		return "nil"
+	}
+
+	return reflect.TypeOf(v).String()
+}
@@ -0,0 +1,115 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	value := struct {
+		Flag   bool   `tlv:"0x01"`
+		Number uint16 `tlv:"0x02"`
+		Text   string `tlv:"0x03,omitempty"`
+		Empty  string `tlv:"0x04,omitempty"`
+	}{Flag: true, Number: 0x1234, Text: "ab"}
+
+	res, err := Marshal(value)
+
+	require.Nil(t, err)
+	require.Equal(t, []byte{
+		0x00, 0x01, 0x00, 0x01, 0x01,
+		0x00, 0x02, 0x00, 0x02, 0x12, 0x34,
+		0x00, 0x03, 0x00, 0x02, 'a', 'b',
+	}, res)
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	silent := true
+	expected := taggedEnvelope{Message: taggedMessage{Notifications: []taggedPushNotification{
+		{Title: "Hello there!", ActionID: 12345678, Timestamp: time.Unix(1625067296, 0).UTC()},
+		{Title: "You there?", ActionID: 240, Timestamp: time.Unix(1614571323, 0).UTC(), Silent: &silent},
+	}}}
+
+	encoded, err := Marshal(&expected)
+	require.Nil(t, err)
+
+	var res taggedEnvelope
+	err = Unmarshal(encoded, &res)
+
+	require.Nil(t, err)
+	require.Equal(t, expected, res)
+}
+
+func TestEncoder_Marshal_WithCustomConfiguration(t *testing.T) {
+	e := MustCreateEncoder(1, 1, binary.LittleEndian)
+	value := struct {
+		Value  uint32   `tlv:"7"`
+		Values []uint8  `tlv:"8"`
+		Nested struct{} `tlv:"9"`
+	}{Value: 0x1234, Values: []uint8{1, 2}}
+
+	res, err := e.Marshal(value)
+
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x07, 0x04, 0x34, 0x12, 0x00, 0x00, 0x08, 0x02, 0x01, 0x02, 0x09, 0x00}, res)
+}
+
+func TestMarshal_WhenTheTargetIsInvalid(t *testing.T) {
+	scenarios := []interface{}{nil, "text", (*taggedEnvelope)(nil)}
+
+	for _, target := range scenarios {
+		res, err := Marshal(target)
+
+		require.Nil(t, res)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "invalid target type")
+	}
+}
+
+func TestMarshal_WhenTheFieldTypeIsUnsupported(t *testing.T) {
+	value := struct {
+		Values []map[string]int `tlv:"0x01"`
+	}{Values: []map[string]int{{}}}
+
+	res, err := Marshal(value)
+
+	require.Nil(t, res)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unsupported type map[string]int on field Values")
+}
+
+func TestMarshal_WhenTheStructTagHasUnknownOptions(t *testing.T) {
+	value := struct {
+		Value string `tlv:"0x01,required"`
+	}{}
+
+	res, err := Marshal(value)
+
+	require.Nil(t, res)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "invalid tlv struct tag")
+}
+
+func TestMarshal_RoundTripWithSignedAndFloatValues(t *testing.T) {
+	type reading struct {
+		Offset  int16   `tlv:"0x01"`
+		Delta   int64   `tlv:"0x02"`
+		Small   int8    `tlv:"0x03"`
+		Celsius float32 `tlv:"0x04"`
+		Precise float64 `tlv:"0x05"`
+	}
+	expected := reading{Offset: -300, Delta: -1, Small: -128, Celsius: -12.5, Precise: 1e-10}
+
+	encoded, err := Marshal(expected)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x01, 0x00, 0x02, 0xfe, 0xd4}, encoded[:6])
+
+	var res reading
+	err = Unmarshal(encoded, &res)
+
+	require.Nil(t, err)
+	require.Equal(t, expected, res)
+}
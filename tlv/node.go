@@ -3,6 +3,8 @@ package tlv
 import (
 	"encoding/base64"
 	"encoding/binary"
+	"errors"
+	"math"
 	"time"
 
 	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
@@ -16,7 +18,11 @@ type Node struct {
 	Value  []byte
 	Raw    []byte
 
-	decoder Decoder
+	decoder *decoder
+	offset  uint64      // offset of the node in the original buffer
+	path    []Tag       // tags of the parent nodes
+	index   *childIndex // indexed nested nodes, when decoded with WithTagIndex
+	count   *nodeCount  // nodes decoded from the same root, when MaxNodes is set
 }
 
 // Tag node identifier composed by 1 to 8 bytes (uint64).
@@ -25,14 +31,88 @@ type Tag uint64
 // Length value size in bytes.
 type Length uint64
 
-// String converts the node bytes to base64.
+// String converts the node bytes to base64, prefixed by the tag name when there is a schema.
 func (n *Node) String() string {
-	return base64.StdEncoding.EncodeToString(n.Raw)
+	encoded := base64.StdEncoding.EncodeToString(n.Raw)
+	if name := n.GetName(); name != "" {
+		return name + ": " + encoded
+	}
+
+	return encoded
+}
+
+// GetName returns the tag name defined in the decoder schema, or an empty string.
+func (n *Node) GetName() string {
+	return n.getSafeDecoder().schema.GetName(n.Tag)
+}
+
+// IsNested returns whether the value holds nested TLV according to the decoder schema
+// or, for BER-TLV, the constructed bit of the tag.
+func (n *Node) IsNested() bool {
+	return n.getSafeDecoder().isNested(n.Tag)
+}
+
+// GetOffset returns the offset of the node in the original buffer, including for nested nodes decoded
+// with GetNodes. Nodes created manually or from trees have zero offsets.
+func (n *Node) GetOffset() uint64 {
+	return n.offset
+}
+
+// GetValueOffset returns the offset of the value in the original buffer, right after the node header.
+func (n *Node) GetValueOffset() uint64 {
+	return n.getValueOffset()
+}
+
+// GetDepth returns the nesting level of the node, root nodes being at level 1.
+func (n *Node) GetDepth() int {
+	return len(n.path) + 1
+}
+
+// GetPath returns the tags of the parent nodes, from the root down to the direct parent.
+// It is empty for root nodes.
+func (n *Node) GetPath() []Tag {
+	return copyPath(n.path)
 }
 
 // GetNodes parses the value as decoded TLV nodes.
+// Decoding errors carry the offset in the original buffer and the tags of the parent nodes.
 func (n *Node) GetNodes() (Nodes, error) {
-	return n.getSafeDecoder().DecodeBytes(n.Value)
+	return n.getSafeDecoder().decodeBytes(n.Value, n.getValueOffset(), n.getChildPath(), n.count)
+}
+
+// decodeChildren parses the value as TLV nodes without validating them against the schema.
+func (n *Node) decodeChildren() (Nodes, error) {
+	return n.getSafeDecoder().decodeSiblings(nil, n.Value, n.getValueOffset(), n.getChildPath(), n.count)
+}
+
+// decodeNested parses the value as TLV nodes when it is not empty, decodes successfully
+// and is not defined by the schema as holding something else. Other values are leaves, so it only
+// fails when decoding them would exceed MaxNodes.
+func (n *Node) decodeNested() (Nodes, bool, error) {
+	if len(n.Value) == 0 {
+		return nil, false, nil
+	}
+
+	if _, defined := n.getSafeDecoder().schema.Lookup(n.Tag); defined && !n.IsNested() {
+		return nil, false, nil
+	}
+
+	children, err := n.decodeChildren()
+	if errors.Is(err, ErrTooManyNodes) {
+		return nil, false, err
+	}
+
+	return children, err == nil, nil
+}
+
+func (n *Node) getChildPath() []Tag {
+	return append(n.path[:len(n.path):len(n.path)], n.Tag)
+}
+
+// IsConstructed returns whether the tag marks the value as nested TLV.
+// Only BER-TLV tags carry this information, so it is always false for other decoders.
+func (n *Node) IsConstructed() bool {
+	return n.getSafeDecoder().header.isConstructed(n.Tag)
 }
 
 // GetBool parses the value as boolean if it has enough bytes.
@@ -94,9 +174,7 @@ func (n *Node) GetUint16() (res uint16, ok bool) {
 
 // GetPaddedUint16 parses the value as uint16 regardless of size.
 func (n *Node) GetPaddedUint16() uint16 {
-	padding := utils.GetPadding(sizes.Uint16, len(n.Value))
-
-	return n.getByteOrder().Uint16(append(padding, n.Value...))
+	return uint16(n.getPaddedValue(sizes.Uint16))
 }
 
 // GetUint32 parses the value as uint32 if it has enough bytes.
@@ -110,9 +188,7 @@ func (n *Node) GetUint32() (res uint32, exists bool) {
 
 // GetPaddedUint32 parses the value as uint32 regardless of size.
 func (n *Node) GetPaddedUint32() uint32 {
-	padding := utils.GetPadding(sizes.Uint32, len(n.Value))
-
-	return n.getByteOrder().Uint32(append(padding, n.Value...))
+	return uint32(n.getPaddedValue(sizes.Uint32))
 }
 
 // GetUint64 parses the value as uint64 if it has enough bytes.
@@ -126,12 +202,92 @@ func (n *Node) GetUint64() (res uint64, ok bool) {
 
 // GetPaddedUint64 parses the value as uint64 regardless of size.
 func (n *Node) GetPaddedUint64() uint64 {
-	padding := utils.GetPadding(sizes.Uint64, len(n.Value))
+	return n.getPaddedValue(sizes.Uint64)
+}
+
+// GetInt8 parses the value as int8.
+func (n *Node) GetInt8() (res int8, ok bool) {
+	if len(n.Value) < sizes.Int8 {
+		return 0, false
+	}
 
-	return n.getByteOrder().Uint64(append(padding, n.Value...))
+	return int8(n.Value[0]), true
 }
 
-func (n *Node) getSafeDecoder() Decoder {
+// GetPaddedInt8 parses the value as int8 regardless of size.
+func (n *Node) GetPaddedInt8() int8 {
+	return int8(n.GetPaddedUint8())
+}
+
+// GetInt16 parses the value as int16 if it has enough bytes.
+func (n *Node) GetInt16() (res int16, ok bool) {
+	value, ok := n.GetUint16()
+	return int16(value), ok
+}
+
+// GetPaddedInt16 parses the value as int16 regardless of size, extending the sign of shorter values.
+func (n *Node) GetPaddedInt16() int16 {
+	return int16(utils.SignExtend(uint64(n.GetPaddedUint16()), n.getValueBits(sizes.Int16)))
+}
+
+// GetInt32 parses the value as int32 if it has enough bytes.
+func (n *Node) GetInt32() (res int32, ok bool) {
+	value, ok := n.GetUint32()
+	return int32(value), ok
+}
+
+// GetPaddedInt32 parses the value as int32 regardless of size, extending the sign of shorter values.
+func (n *Node) GetPaddedInt32() int32 {
+	return int32(utils.SignExtend(uint64(n.GetPaddedUint32()), n.getValueBits(sizes.Int32)))
+}
+
+// GetInt64 parses the value as int64 if it has enough bytes.
+func (n *Node) GetInt64() (res int64, ok bool) {
+	value, ok := n.GetUint64()
+	return int64(value), ok
+}
+
+// GetPaddedInt64 parses the value as int64 regardless of size, extending the sign of shorter values.
+func (n *Node) GetPaddedInt64() int64 {
+	return utils.SignExtend(n.GetPaddedUint64(), n.getValueBits(sizes.Int64))
+}
+
+// GetFloat32 parses the value as IEEE-754 float32 if it has enough bytes.
+func (n *Node) GetFloat32() (res float32, ok bool) {
+	bits, ok := n.GetUint32()
+	if !ok {
+		return 0, false
+	}
+
+	return math.Float32frombits(bits), true
+}
+
+// GetFloat64 parses the value as IEEE-754 float64 if it has enough bytes.
+func (n *Node) GetFloat64() (res float64, ok bool) {
+	bits, ok := n.GetUint64()
+	if !ok {
+		return 0, false
+	}
+
+	return math.Float64frombits(bits), true
+}
+
+// getPaddedValue parses up to typeSize bytes of the value as an unsigned integer without allocating.
+func (n *Node) getPaddedValue(typeSize int) uint64 {
+	value := n.Value
+	if len(value) > typeSize {
+		value = value[:typeSize]
+	}
+
+	return utils.ReadUint(value, n.getSafeDecoder().littleEndian)
+}
+
+// getValueBits returns how many bits of the value are used when parsing a type of the given size.
+func (n *Node) getValueBits(typeSize int) int {
+	return bitsPerByte * utils.MinInt(len(n.Value), typeSize)
+}
+
+func (n *Node) getSafeDecoder() *decoder {
 	if n.decoder != nil {
 		return n.decoder
 	}
@@ -139,6 +295,15 @@ func (n *Node) getSafeDecoder() Decoder {
 	return stdDecoder
 }
 
+// getValueOffset returns the offset of the value in the original buffer.
+func (n *Node) getValueOffset() uint64 {
+	if headerSize := len(n.Raw) - len(n.Value); headerSize > 0 {
+		return n.offset + uint64(headerSize)
+	}
+
+	return n.offset
+}
+
 func (n *Node) getByteOrder() binary.ByteOrder {
 	return n.getSafeDecoder().GetByteOrder()
 }
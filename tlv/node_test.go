@@ -1,6 +1,7 @@
 package tlv
 
 import (
+	"encoding/binary"
 	"testing"
 	"time"
 
@@ -38,6 +39,53 @@ func TestNode_GetNodes_WhenTheValueIsInvalid(t *testing.T) {
 	require.Empty(t, nodes)
 }
 
+func TestNode_GetOffset(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	notifications, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	fields, err := notifications[1].GetNodes()
+	require.Nil(t, err)
+
+	require.Equal(t, uint64(0), nodes[0].GetOffset())
+	require.Equal(t, uint64(4), nodes[0].GetValueOffset())
+	require.Equal(t, uint64(39), notifications[1].GetOffset())
+	require.Equal(t, uint64(57), fields[1].GetOffset())
+	require.Equal(t, uint64(61), fields[1].GetValueOffset())
+	require.Equal(t, fields[1].Raw, data[57:57+len(fields[1].Raw)])
+}
+
+func TestNode_GetPath(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	notifications, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	fields, err := notifications[0].GetNodes()
+	require.Nil(t, err)
+
+	require.Empty(t, nodes[0].GetPath())
+	require.Equal(t, 1, nodes[0].GetDepth())
+	require.Equal(t, []Tag{tagMessage, tagPushNotification}, fields[0].GetPath())
+	require.Equal(t, 3, fields[0].GetDepth())
+
+	path := fields[0].GetPath()
+	path[0] = 0xff
+	require.Equal(t, []Tag{tagMessage, tagPushNotification}, fields[0].GetPath())
+}
+
+func TestNode_GetOffset_WhenCreatedManually(t *testing.T) {
+	node := NewNode(tagTitle, []byte("title"))
+
+	require.Equal(t, uint64(0), node.GetOffset())
+	require.Equal(t, uint64(0), node.GetValueOffset())
+	require.Equal(t, 1, node.GetDepth())
+	require.Empty(t, node.GetPath())
+}
+
 func TestNode_GetBool(t *testing.T) {
 	scenarios := testScenarios{
 		newNode([]byte{0xff, 0x12}): {true, true},   // bigger
@@ -269,3 +317,201 @@ func newNode(value []byte) *Node {
 	node := NewNode(Tag(0x01), value)
 	return &node
 }
+
+func TestNode_GetPaddedUint32_WhenTheByteOrderIsLittleEndian(t *testing.T) {
+	node := MustCreateDecoder(1, 1, binary.LittleEndian).NewNode(0x01, []byte{0x34, 0x12})
+
+	require.Equal(t, uint32(0x1234), node.GetPaddedUint32())
+}
+
+func TestNode_IsConstructed_WhenTheDecoderIsNotBER(t *testing.T) {
+	node := NewNode(0x20, nil)
+
+	require.False(t, node.IsConstructed())
+}
+
+func TestNode_GetInt8(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0xfe, 0xff}): {int8(-2), true},  // bigger
+		newNode([]byte{0x7f}):       {int8(127), true}, // exact size
+		newNode([]byte{}):           {int8(0), false},  // empty
+		newNode(nil):                {int8(0), false},  // nil
+	}
+
+	for node, expected := range scenarios {
+		res, ok := node.GetInt8()
+
+		require.Equal(t, expected.res, res)
+		require.Equal(t, expected.ok, ok)
+	}
+}
+
+func TestNode_GetPaddedInt8(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0x80, 0x12}): {res: int8(-128)}, // bigger
+		newNode([]byte{0xff}):       {res: int8(-1)},   // exact size
+		newNode([]byte{}):           {res: int8(0)},    // empty
+	}
+
+	for node, expected := range scenarios {
+		require.Equal(t, expected.res, node.GetPaddedInt8())
+	}
+}
+
+func TestNode_GetInt16(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0xff, 0xfe, 0xff}): {int16(-2), true},   // bigger
+		newNode([]byte{0x12, 0x34}):       {int16(4660), true}, // exact size
+		newNode([]byte{0xff}):             {int16(0), false},   // smaller
+		newNode(nil):                      {int16(0), false},   // nil
+	}
+
+	for node, expected := range scenarios {
+		res, ok := node.GetInt16()
+
+		require.Equal(t, expected.res, res)
+		require.Equal(t, expected.ok, ok)
+	}
+}
+
+func TestNode_GetPaddedInt16(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0xff, 0xfe, 0xff}): {res: int16(-2)},     // bigger
+		newNode([]byte{0x80, 0x00}):       {res: int16(-32768)}, // exact size
+		newNode([]byte{0xff}):             {res: int16(-1)},     // smaller (negative)
+		newNode([]byte{0x7f}):             {res: int16(127)},    // smaller (positive)
+		newNode(nil):                      {res: int16(0)},      // nil
+	}
+
+	for node, expected := range scenarios {
+		require.Equal(t, expected.res, node.GetPaddedInt16())
+	}
+}
+
+func TestNode_GetInt32(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0xff, 0xff, 0xff, 0xfe, 0x00}): {int32(-2), true},  // bigger
+		newNode([]byte{0x00, 0x00, 0x01, 0x00}):       {int32(256), true}, // exact size
+		newNode([]byte{0xff, 0xff, 0xff}):             {int32(0), false},  // smaller
+	}
+
+	for node, expected := range scenarios {
+		res, ok := node.GetInt32()
+
+		require.Equal(t, expected.res, res)
+		require.Equal(t, expected.ok, ok)
+	}
+}
+
+func TestNode_GetPaddedInt32(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0xff, 0xff, 0xff, 0xfe}): {res: int32(-2)},     // exact size
+		newNode([]byte{0xff, 0x85, 0xee}):       {res: int32(-31250)}, // smaller (negative)
+		newNode([]byte{0x00, 0x85, 0xee}):       {res: int32(34286)},  // smaller (positive)
+		newNode([]byte{}):                       {res: int32(0)},      // empty
+	}
+
+	for node, expected := range scenarios {
+		require.Equal(t, expected.res, node.GetPaddedInt32())
+	}
+}
+
+func TestNode_GetInt64(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}): {int64(-2), true}, // exact size
+		newNode([]byte{0xff, 0xff, 0xff, 0xff}):                         {int64(0), false}, // smaller
+	}
+
+	for node, expected := range scenarios {
+		res, ok := node.GetInt64()
+
+		require.Equal(t, expected.res, res)
+		require.Equal(t, expected.ok, ok)
+	}
+}
+
+func TestNode_GetPaddedInt64(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe, 0x12}): {res: int64(-2)}, // bigger
+		newNode([]byte{0xff, 0xfe}): {res: int64(-2)},  // smaller (negative)
+		newNode([]byte{0x01, 0x00}): {res: int64(256)}, // smaller (positive)
+		newNode(nil):                {res: int64(0)},   // nil
+	}
+
+	for node, expected := range scenarios {
+		require.Equal(t, expected.res, node.GetPaddedInt64())
+	}
+}
+
+func TestNode_GetPaddedInt32_WhenTheByteOrderIsLittleEndian(t *testing.T) {
+	node := MustCreateDecoder(1, 1, binary.LittleEndian).NewNode(0x01, []byte{0xfe, 0xff})
+
+	require.Equal(t, int32(-2), node.GetPaddedInt32())
+}
+
+func TestNode_GetFloat32(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0x40, 0x49, 0x0f, 0xdb, 0x00}): {float32(3.1415927), true}, // bigger
+		newNode([]byte{0xc0, 0x00, 0x00, 0x00}):       {float32(-2), true},        // exact size
+		newNode([]byte{0x40, 0x49}):                   {float32(0), false},        // smaller
+	}
+
+	for node, expected := range scenarios {
+		res, ok := node.GetFloat32()
+
+		require.Equal(t, expected.res, res)
+		require.Equal(t, expected.ok, ok)
+	}
+}
+
+func TestNode_GetFloat64(t *testing.T) {
+	scenarios := testScenarios{
+		newNode([]byte{0x40, 0x09, 0x21, 0xfb, 0x54, 0x44, 0x2d, 0x18}): {3.141592653589793, true}, // exact size
+		newNode([]byte{0x40, 0x49, 0x0f, 0xdb}):                         {float64(0), false},       // smaller
+	}
+
+	for node, expected := range scenarios {
+		res, ok := node.GetFloat64()
+
+		require.Equal(t, expected.res, res)
+		require.Equal(t, expected.ok, ok)
+	}
+}
+
+func TestNode_GetFloat64_WhenTheByteOrderIsLittleEndian(t *testing.T) {
+	value := []byte{0x18, 0x2d, 0x44, 0x54, 0xfb, 0x21, 0x09, 0x40}
+	node := MustCreateDecoder(1, 1, binary.LittleEndian).NewNode(0x01, value)
+
+	res, ok := node.GetFloat64()
+
+	require.True(t, ok)
+	require.Equal(t, 3.141592653589793, res)
+}
+
+func TestNode_NumericGetters_DoNotAllocate(t *testing.T) {
+	value := []byte{0x81, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+
+	for size := 1; size <= len(value); size++ {
+		for _, byteOrder := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			node := MustCreateDecoder(2, 2, byteOrder).NewNode(0x01, value[:size])
+
+			allocs := testing.AllocsPerRun(100, func() {
+				node.GetPaddedUint8()
+				node.GetPaddedUint16()
+				node.GetPaddedUint32()
+				node.GetPaddedUint64()
+				node.GetPaddedInt16()
+				node.GetPaddedInt32()
+				node.GetPaddedInt64()
+				node.GetUint16()
+				node.GetUint32()
+				node.GetUint64()
+				node.GetFloat32()
+				node.GetFloat64()
+				node.GetDate()
+			})
+
+			require.Zero(t, allocs)
+		}
+	}
+}
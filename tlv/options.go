@@ -0,0 +1,132 @@
+package tlv
+
+import (
+	"encoding/binary"
+
+	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
+)
+
+// Option configures a [Decoder] created by [CreateDecoderWithOptions].
+type Option func(*decoderConfig)
+
+type decoderConfig struct {
+	tagSize    uint8
+	lengthSize uint8
+	byteOrder  binary.ByteOrder
+	ber        bool
+	schema     *Schema
+	limits     Limits
+	strict     strictMode
+	tagIndex   bool
+	copyValues bool
+}
+
+// MustCreateDecoderWithOptions creates a [Decoder] configured by the options or panics in case of any errors.
+func MustCreateDecoderWithOptions(options ...Option) Decoder {
+	res, err := CreateDecoderWithOptions(options...)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// CreateDecoderWithOptions creates a [Decoder] configured by the options. Without options, it has
+// the same configuration as the standard decoder: 2-byte tags and lengths parsed as big endian.
+func CreateDecoderWithOptions(options ...Option) (Decoder, error) {
+	config := decoderConfig{tagSize: sizes.Uint16, lengthSize: sizes.Uint16, byteOrder: stdByteOrder}
+	for _, option := range options {
+		option(&config)
+	}
+
+	var res *decoder
+	if config.ber {
+		res = newDecoder(&berHeader{}, config.byteOrder)
+	} else {
+		if err := validateSizes(config.tagSize, config.lengthSize); err != nil {
+			return nil, err
+		}
+		res = newFixedDecoder(config.tagSize, config.lengthSize, config.byteOrder)
+	}
+
+	res.schema = config.schema
+	res.limits = config.limits
+	res.strict = config.strict
+	res.tagIndex = config.tagIndex
+	res.copyValues = config.copyValues
+
+	return res, nil
+}
+
+// WithSizes sets how many bytes tags and lengths take (between 1 and 8).
+func WithSizes(tagSize, lengthSize uint8) Option {
+	return func(c *decoderConfig) {
+		c.tagSize, c.lengthSize = tagSize, lengthSize
+	}
+}
+
+// WithByteOrder sets the endianness of tags, lengths and numeric values.
+func WithByteOrder(byteOrder binary.ByteOrder) Option {
+	return func(c *decoderConfig) {
+		c.byteOrder = byteOrder
+	}
+}
+
+// WithBER uses BER-TLV tags and lengths (see [CreateBERDecoder]) instead of fixed sizes.
+func WithBER() Option {
+	return func(c *decoderConfig) {
+		c.ber = true
+	}
+}
+
+// WithSchema validates and names nodes with the schema (see [Decoder.WithSchema]).
+func WithSchema(schema *Schema) Option {
+	return func(c *decoderConfig) {
+		c.schema = schema
+	}
+}
+
+// WithLimits enforces the limits when decoding (see [Decoder.WithLimits]).
+func WithLimits(limits Limits) Option {
+	return func(c *decoderConfig) {
+		c.limits = limits
+	}
+}
+
+// WithStrictTrailingData rejects bytes left after the last node with a [TrailingDataError]:
+// DecodeSingle fails instead of ignoring them and other decoding methods report a truncated node after
+// at least one node (too short for its header or for its value) as trailing data instead of a
+// [MessageTooShortError] or a [LengthMismatchError].
+func WithStrictTrailingData() Option {
+	return func(c *decoderConfig) {
+		c.strict.trailingData = true
+	}
+}
+
+// WithStrictEmptyValues rejects nodes with the tags and a zero-length value with an [EmptyValueError].
+func WithStrictEmptyValues(tags ...Tag) Option {
+	return func(c *decoderConfig) {
+		if c.strict.emptyValues == nil {
+			c.strict.emptyValues = make(map[Tag]struct{}, len(tags))
+		}
+
+		for _, tag := range tags {
+			c.strict.emptyValues[tag] = struct{}{}
+		}
+	}
+}
+
+// WithStrictDuplicateTags rejects sibling nodes with the same tag with a [DuplicateNodeError].
+// Nodes read by a [Scanner] are compared with all the top-level nodes read before them, as in DecodeBytes.
+func WithStrictDuplicateTags() Option {
+	return func(c *decoderConfig) {
+		c.strict.duplicateTags = true
+	}
+}
+
+// WithStrictZeroTags rejects nodes whose tag is zero with a [ZeroTagError].
+func WithStrictZeroTags() Option {
+	return func(c *decoderConfig) {
+		c.strict.zeroTags = true
+	}
+}
@@ -0,0 +1,66 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDecoderWithOptions(t *testing.T) {
+	limits := Limits{MaxDepth: 4}
+
+	d, err := CreateDecoderWithOptions(
+		WithSizes(1, 4),
+		WithByteOrder(binary.LittleEndian),
+		WithSchema(testSchema),
+		WithLimits(limits),
+	)
+
+	require.Nil(t, err)
+	require.Equal(t, binary.LittleEndian, d.GetByteOrder())
+	require.Equal(t, testSchema, d.GetSchema())
+	require.Equal(t, limits, d.GetLimits())
+
+	node, read, err := d.DecodeSingle([]byte{0x05, 0x01, 0x00, 0x00, 0x00, 0xff})
+	require.Nil(t, err)
+	require.Equal(t, uint64(6), read)
+	require.Equal(t, Tag(0x05), node.Tag)
+}
+
+func TestCreateDecoderWithOptions_WithDefaults(t *testing.T) {
+	d, err := CreateDecoderWithOptions()
+	require.Nil(t, err)
+
+	nodes, err := d.DecodeBytes(data)
+
+	require.Nil(t, err)
+	require.Equal(t, binary.BigEndian, d.GetByteOrder())
+	require.Len(t, nodes, 1)
+}
+
+func TestCreateDecoderWithOptions_WithBER(t *testing.T) {
+	d, err := CreateDecoderWithOptions(WithBER(), WithSizes(0, 0))
+	require.Nil(t, err)
+
+	node, _, err := d.DecodeSingle([]byte{0x9f, 0x02, 0x01, 0xff})
+
+	require.Nil(t, err)
+	require.Equal(t, Tag(0x9f02), node.Tag)
+}
+
+func TestCreateDecoderWithOptions_WhenTheSizesAreInvalid(t *testing.T) {
+	d, err := CreateDecoderWithOptions(WithSizes(9, 2))
+
+	require.Nil(t, d)
+	require.True(t, errors.Is(err, ErrInvalidSize))
+}
+
+func TestMustCreateDecoderWithOptions_WhenTheSizesAreInvalid(t *testing.T) {
+	defer func() {
+		require.NotNil(t, recover())
+	}()
+
+	_ = MustCreateDecoderWithOptions(WithSizes(2, 0))
+}
@@ -0,0 +1,144 @@
+package tlv
+
+import "errors"
+
+// Recovery selects how [Decoder.DecodePartial] continues after finding a corrupted node.
+type Recovery uint8
+
+// Recovery modes supported by [Decoder.DecodePartial].
+const (
+	RecoveryStop   Recovery = iota // Stops at the first node that cannot be decoded.
+	RecoveryResync                 // Skips bytes until the next position where nodes can be decoded.
+)
+
+// DecodeFailure describes a root node that could not be decoded by [Decoder.DecodePartial].
+type DecodeFailure struct {
+	Offset  uint64 // Offset of the node in the original buffer.
+	Tag     Tag    // Tag of the node, zero when the header could not be read.
+	Reason  string // Description of the failure.
+	Skipped uint64 // Bytes skipped until the next node (or the end of the data) when resynchronizing.
+	Err     error  // Typed error found, e.g. *LengthMismatchError.
+}
+
+type partialDecoding struct {
+	decoder  *decoder
+	data     []byte
+	recovery Recovery
+	checker  strictChecker
+	nodes    Nodes
+	failures []DecodeFailure
+	count    *nodeCount
+}
+
+// DecodePartial decodes as many root nodes as possible instead of failing on the first corrupted one.
+// It returns the nodes decoded successfully and, if any node failed, a *PartialDecodeError listing
+// each failure. With [RecoveryStop] decoding ends at the first failure, while with [RecoveryResync]
+// it moves forward one byte at a time until a node can be decoded and is followed either by the end
+// of the data or by another node (and, when there is a schema, has a defined tag).
+// Nodes violating the schema or the strict mode are reported as failures without interrupting the decoding.
+func (d *decoder) DecodePartial(data []byte, recovery Recovery) (Nodes, error) {
+	p := partialDecoding{
+		decoder:  d,
+		data:     data,
+		recovery: recovery,
+		checker:  d.newStrictChecker(),
+		count:    d.newNodeCount(),
+	}
+	p.decode()
+	d.ownNodes(p.nodes)
+
+	if len(p.failures) == 0 {
+		return p.nodes, nil
+	}
+
+	return p.nodes, &PartialDecodeError{Decoded: len(p.nodes), Failures: p.failures}
+}
+
+func (p *partialDecoding) decode() {
+	for offset := uint64(0); offset < uint64(len(p.data)); {
+		node, read, err := p.decoder.decodeSingle(p.data[offset:], offset, nil)
+		if err == nil {
+			if err = p.decoder.countNode(p.count, offset, nil); err != nil {
+				p.failures = append(p.failures, newDecodeFailure(offset, err))
+				return
+			}
+
+			node.count = p.count
+			p.appendNode(node)
+			offset += read
+			continue
+		}
+
+		failure := newDecodeFailure(offset, err)
+		if p.recovery != RecoveryResync {
+			p.failures = append(p.failures, failure)
+			return
+		}
+
+		next := p.resync(offset + 1)
+		failure.Skipped = next - offset
+		p.failures = append(p.failures, failure)
+		offset = next
+	}
+}
+
+// appendNode keeps the node if it is valid according to the strict mode and the schema,
+// or reports it as a failure.
+func (p *partialDecoding) appendNode(node Node) {
+	err := p.checker.check(&node)
+	if err == nil {
+		err = p.decoder.validate(Nodes{node}, nil)
+	}
+
+	if err != nil {
+		failure := newDecodeFailure(node.offset, err)
+		failure.Tag = node.Tag
+		p.failures = append(p.failures, failure)
+		return
+	}
+
+	p.nodes = append(p.nodes, node)
+}
+
+// resync returns the first offset from start where a node boundary is found, or the data length.
+func (p *partialDecoding) resync(start uint64) uint64 {
+	for offset := start; offset < uint64(len(p.data)); offset++ {
+		if p.isNodeBoundary(offset) {
+			return offset
+		}
+	}
+
+	return uint64(len(p.data))
+}
+
+func (p *partialDecoding) isNodeBoundary(offset uint64) bool {
+	node, read, err := p.decoder.decodeSingle(p.data[offset:], offset, nil)
+	if err != nil {
+		return false
+	}
+
+	if schema := p.decoder.schema; schema != nil {
+		if _, ok := schema.Lookup(node.Tag); !ok {
+			return false
+		}
+	}
+
+	next := offset + read
+	if next == uint64(len(p.data)) {
+		return true
+	}
+
+	_, _, err = p.decoder.decodeSingle(p.data[next:], next, nil)
+	return err == nil
+}
+
+func newDecodeFailure(offset uint64, err error) DecodeFailure {
+	res := DecodeFailure{Offset: offset, Reason: err.Error(), Err: err}
+
+	var mismatchErr *LengthMismatchError
+	if errors.As(err, &mismatchErr) {
+		res.Tag = mismatchErr.Tag
+	}
+
+	return res
+}
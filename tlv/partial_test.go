@@ -0,0 +1,94 @@
+package tlv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var corruptedData = []byte{
+	0x00, 0x01, 0x00, 0x02, 0x61, 0x61, // valid
+	0x00, 0x02, 0xff, 0x00, 0x62, 0x62, // corrupted length
+	0x00, 0x03, 0x00, 0x02, 0x63, 0x63, // valid
+	0x00, 0x04, 0x00, 0x02, 0x64, 0x64, // valid
+}
+
+func TestDecoder_DecodePartial(t *testing.T) {
+	nodes, err := DecodePartial(data, RecoveryStop)
+
+	require.Nil(t, err)
+	require.Len(t, nodes, 1)
+}
+
+func TestDecoder_DecodePartial_WhenTheLastNodeIsTruncated(t *testing.T) {
+	nodes, err := DecodePartial(data[:len(data)-1], RecoveryStop)
+
+	require.Empty(t, nodes)
+
+	var partialErr *PartialDecodeError
+	require.True(t, errors.As(err, &partialErr))
+	require.True(t, errors.Is(err, ErrPartialDecode))
+	require.Equal(t, 0, partialErr.Decoded)
+	require.Len(t, partialErr.Failures, 1)
+	require.Equal(t, tagMessage, partialErr.Failures[0].Tag)
+	require.True(t, errors.Is(partialErr.Failures[0].Err, ErrLengthMismatch))
+}
+
+func TestDecoder_DecodePartial_WithRecoveryStop(t *testing.T) {
+	nodes, err := DecodePartial(corruptedData, RecoveryStop)
+
+	require.Len(t, nodes, 1)
+	require.Equal(t, Tag(0x0001), nodes[0].Tag)
+
+	var partialErr *PartialDecodeError
+	require.True(t, errors.As(err, &partialErr))
+	require.Equal(t, []DecodeFailure{{
+		Offset: 6,
+		Tag:    0x0002,
+		Reason: partialErr.Failures[0].Err.Error(),
+		Err:    partialErr.Failures[0].Err,
+	}}, partialErr.Failures)
+}
+
+func TestDecoder_DecodePartial_WithRecoveryResync(t *testing.T) {
+	nodes, err := DecodePartial(corruptedData, RecoveryResync)
+
+	require.Len(t, nodes, 3)
+	require.Equal(t, []Tag{0x0001, 0x0003, 0x0004}, []Tag{nodes[0].Tag, nodes[1].Tag, nodes[2].Tag})
+	require.Equal(t, uint64(12), nodes[1].offset)
+
+	var partialErr *PartialDecodeError
+	require.True(t, errors.As(err, &partialErr))
+	require.Equal(t, 3, partialErr.Decoded)
+	require.Len(t, partialErr.Failures, 1)
+	require.Equal(t, uint64(6), partialErr.Failures[0].Offset)
+	require.Equal(t, uint64(6), partialErr.Failures[0].Skipped)
+}
+
+func TestDecoder_DecodePartial_WhenThereIsNoBoundaryToResync(t *testing.T) {
+	nodes, err := DecodePartial(append(corruptedData[:6:6], 0xff, 0xff, 0xff), RecoveryResync)
+
+	require.Len(t, nodes, 1)
+
+	var partialErr *PartialDecodeError
+	require.True(t, errors.As(err, &partialErr))
+	require.Equal(t, uint64(3), partialErr.Failures[0].Skipped)
+	require.True(t, errors.Is(partialErr.Failures[0].Err, ErrMessageTooShort))
+	require.Zero(t, partialErr.Failures[0].Tag)
+}
+
+func TestDecoder_DecodePartial_WhenANodeViolatesTheSchema(t *testing.T) {
+	input, err := EncodeNodes(Nodes{NewNode(tagTitle, nil), NewNode(tagActionID, []byte{0x01})})
+	require.Nil(t, err)
+
+	nodes, err := stdDecoder.WithSchema(testSchema).DecodePartial(input, RecoveryStop)
+
+	require.Len(t, nodes, 1)
+	require.Equal(t, tagActionID, nodes[0].Tag)
+
+	var partialErr *PartialDecodeError
+	require.True(t, errors.As(err, &partialErr))
+	require.Equal(t, tagTitle, partialErr.Failures[0].Tag)
+	require.True(t, errors.Is(partialErr.Failures[0].Err, ErrSchemaViolation))
+}
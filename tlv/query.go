@@ -0,0 +1,195 @@
+package tlv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	pathSeparator   = "/"
+	pathWildcard    = "*"
+	pathIndexPrefix = "["
+	pathIndexSuffix = "]"
+)
+
+// PathSegment selects nodes at one level of a [Path].
+type PathSegment struct {
+	Tag      Tag  // Tag of the selected nodes, ignored for wildcards.
+	Wildcard bool // Selects nodes with any tag.
+	Indexed  bool // Selects a single node among the siblings matching the segment.
+	Index    int  // Zero-based position of the selected node, used only when Indexed.
+}
+
+// Path is a sequence of segments used to query nested [Nodes], one segment per level.
+type Path []PathSegment
+
+// MatchTag creates a [PathSegment] that selects all nodes with the tag.
+func MatchTag(tag Tag) PathSegment {
+	return PathSegment{Tag: tag}
+}
+
+// MatchIndex creates a [PathSegment] that selects the nth node (zero-based) with the tag.
+func MatchIndex(tag Tag, index int) PathSegment {
+	return PathSegment{Tag: tag, Indexed: true, Index: index}
+}
+
+// MatchAny creates a [PathSegment] that selects all nodes regardless of their tag.
+func MatchAny() PathSegment {
+	return PathSegment{Wildcard: true}
+}
+
+// NewPath creates a [Path] that selects all nodes matching each tag in sequence.
+func NewPath(tags ...Tag) Path {
+	res := make(Path, len(tags))
+	for i, tag := range tags {
+		res[i] = MatchTag(tag)
+	}
+
+	return res
+}
+
+// ParsePath parses a path expression such as "0x0001/0x0101[1]/*", where each segment is a
+// tag (decimal or hexadecimal) or a wildcard (*), optionally followed by a zero-based index.
+func ParsePath(expr string) (Path, error) {
+	parts := strings.Split(strings.TrimPrefix(expr, pathSeparator), pathSeparator)
+
+	res := make(Path, len(parts))
+	for i, part := range parts {
+		segment, err := parsePathSegment(part)
+		if err != nil {
+			return nil, &QueryError{Path: expr, Segment: i, Err: err}
+		}
+
+		res[i] = segment
+	}
+
+	return res, nil
+}
+
+func parsePathSegment(expr string) (PathSegment, error) {
+	var res PathSegment
+
+	selector := expr
+	if start := strings.Index(expr, pathIndexPrefix); start >= 0 {
+		if !strings.HasSuffix(expr, pathIndexSuffix) {
+			return res, fmt.Errorf("%w %q", ErrInvalidPath, expr)
+		}
+
+		index, err := strconv.ParseUint(expr[start+1:len(expr)-1], 10, 31)
+		if err != nil {
+			return res, fmt.Errorf("%w %q", ErrInvalidPath, expr)
+		}
+
+		selector, res.Indexed, res.Index = expr[:start], true, int(index)
+	}
+
+	if selector == pathWildcard {
+		res.Wildcard = true
+		return res, nil
+	}
+
+	tag, err := strconv.ParseUint(selector, 0, 64)
+	if err != nil {
+		return res, fmt.Errorf("%w %q", ErrInvalidPath, expr)
+	}
+
+	res.Tag = Tag(tag)
+	return res, nil
+}
+
+// String formats the path with the same syntax accepted by [ParsePath].
+func (p Path) String() string {
+	parts := make([]string, len(p))
+	for i := range p {
+		parts[i] = p[i].String()
+	}
+
+	return strings.Join(parts, pathSeparator)
+}
+
+// String formats the segment with the same syntax accepted by [ParsePath].
+func (s PathSegment) String() string {
+	res := pathWildcard
+	if !s.Wildcard {
+		res = fmt.Sprintf("0x%x", uint64(s.Tag))
+	}
+
+	if s.Indexed {
+		res += fmt.Sprintf("%s%d%s", pathIndexPrefix, s.Index, pathIndexSuffix)
+	}
+
+	return res
+}
+
+// Query parses the path expression (see [ParsePath]) and returns the nodes it selects.
+func (ns Nodes) Query(expr string) (Nodes, error) {
+	path, err := ParsePath(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return ns.Find(path)
+}
+
+// Find returns the nodes selected by the path, where the first segment applies to the nodes
+// themselves and each following segment to the values of the nodes previously selected.
+// Values are decoded only when a node is selected by a segment that is not the last one.
+// Nodes selected by wildcards whose values are not valid TLV are skipped instead of failing.
+func (ns Nodes) Find(path Path) (Nodes, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	current := path[0].selectNodes(nil, ns)
+
+	for i := 1; i < len(path) && len(current) > 0; i++ {
+		var next Nodes
+
+		for j := range current {
+			if len(current[j].Value) == 0 {
+				continue
+			}
+
+			children, err := current[j].GetNodes()
+			if err != nil {
+				if path[i-1].Wildcard {
+					continue
+				}
+				return nil, &QueryError{Path: path.String(), Segment: i - 1, Err: err}
+			}
+
+			next = path[i].selectNodes(next, children)
+		}
+
+		current = next
+	}
+
+	return current, nil
+}
+
+// selectNodes appends the siblings matching the segment to dst.
+func (s PathSegment) selectNodes(dst, siblings Nodes) Nodes {
+	s.forEach(siblings, func(i int) {
+		dst = append(dst, siblings[i])
+	})
+
+	return dst
+}
+
+// forEach calls fn with the positions of the siblings matching the segment, in ascending order.
+func (s PathSegment) forEach(siblings Nodes, fn func(i int)) {
+	position := 0
+
+	for i := range siblings {
+		if !s.Wildcard && siblings[i].Tag != s.Tag {
+			continue
+		}
+
+		if !s.Indexed || position == s.Index {
+			fn(i)
+		}
+
+		position++
+	}
+}
@@ -0,0 +1,124 @@
+package tlv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	path, err := ParsePath("/0x0001/0x0101[1]/*/258")
+
+	require.Nil(t, err)
+	require.Equal(t, Path{MatchTag(0x0001), MatchIndex(0x0101, 1), MatchAny(), MatchTag(0x0102)}, path)
+	require.Equal(t, "0x1/0x101[1]/*/0x102", path.String())
+}
+
+func TestParsePath_WhenTheSyntaxIsInvalid(t *testing.T) {
+	scenarios := map[string]int{
+		"0x0001/abc":       1,
+		"0x0001/0x0101[1":  1,
+		"0x0001//0x0102":   1,
+		"0x0001[-1]":       0,
+		"*[x]/0x0001":      0,
+		"0x0001/0x0101[]/": 1,
+	}
+
+	for expr, segment := range scenarios {
+		path, err := ParsePath(expr)
+
+		var queryErr *QueryError
+		require.Nil(t, path)
+		require.True(t, errors.As(err, &queryErr), expr)
+		require.Equal(t, segment, queryErr.Segment, expr)
+		require.True(t, errors.Is(err, ErrInvalidPath), expr)
+	}
+}
+
+func TestNodes_Query(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Query("0x0001/0x0101/0x0102")
+
+	require.Nil(t, err)
+	require.Equal(t, 2, len(res))
+	require.Equal(t, "Hello there!", res[0].GetString())
+	require.Equal(t, "You there?", res[1].GetString())
+}
+
+func TestNodes_Query_WithIndexes(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Query("0x0001/0x0101[1]/0x0102")
+
+	require.Nil(t, err)
+	require.Equal(t, 1, len(res))
+	require.Equal(t, "You there?", res[0].GetString())
+}
+
+func TestNodes_Query_WithWildcards(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Query("*/*[0]/*")
+
+	require.Nil(t, err)
+	require.Equal(t, 3, len(res))
+	require.Equal(t, []Tag{tagTitle, tagActionID, tagTimestamp}, []Tag{res[0].Tag, res[1].Tag, res[2].Tag})
+
+	leaves, err := nodes.Query("*/*/*/*")
+	require.Nil(t, err)
+	require.Empty(t, leaves)
+}
+
+func TestNodes_Query_WhenNothingMatches(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Query("0x0001/0x0101[2]/0x0102")
+
+	require.Nil(t, err)
+	require.Empty(t, res)
+}
+
+func TestNodes_Query_WhenAnIntermediateValueIsInvalid(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Query("0x0001/0x0101/0x0102/0x0001")
+
+	var queryErr *QueryError
+	require.Nil(t, res)
+	require.True(t, errors.As(err, &queryErr))
+	require.Equal(t, 2, queryErr.Segment)
+	require.Equal(t, "0x1/0x101/0x102/0x1", queryErr.Path)
+	require.True(t, errors.Is(err, ErrLengthMismatch))
+}
+
+func TestNodes_Query_WhenThePathIsInvalid(t *testing.T) {
+	res, err := Nodes{}.Query("abc")
+
+	require.Nil(t, res)
+	require.True(t, errors.Is(err, ErrInvalidPath))
+}
+
+func TestNodes_Find(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := nodes.Find(NewPath(tagMessage, tagPushNotification, tagSilent))
+
+	require.Nil(t, err)
+	require.Equal(t, 1, len(res))
+	require.True(t, res[0].GetPaddedBool())
+}
+
+func TestNodes_Find_WhenThePathIsEmpty(t *testing.T) {
+	res, err := Nodes{NewNode(0x01, nil)}.Find(nil)
+
+	require.Nil(t, err)
+	require.Nil(t, res)
+}
@@ -0,0 +1,188 @@
+package tlv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+)
+
+// scannerChunkSize limits how much memory is reserved upfront for a value,
+// so truncated frames declaring huge lengths do not allocate beyond the available data.
+const scannerChunkSize = 64 * 1024
+
+// Scanner reads top-level TLV [Nodes] from an [io.Reader] one at a time.
+// Only the current node is kept in memory, so it is suitable for large files and
+// long-lived connections. Each node owns its bytes and remains valid after the next scan.
+type Scanner struct {
+	reader  io.Reader
+	decoder *decoder
+	offset  uint64
+	decoded int
+	count   *nodeCount
+	checker strictChecker
+	node    Node
+	err     error
+}
+
+// NewScanner creates a [Scanner] that decodes nodes from the reader using the [Decoder] configuration.
+func (d *decoder) NewScanner(reader io.Reader) *Scanner {
+	return &Scanner{reader: reader, decoder: d, count: d.newNodeCount(), checker: d.newStrictChecker()}
+}
+
+// Scan reads the next [Node] from the reader, which is then available through the Node method.
+// It returns false when the reader is exhausted or an error occurs (see Err).
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	node, err := s.readNode()
+	if err != nil {
+		s.node, s.err = Node{}, err
+		return false
+	}
+
+	s.node = node
+	return true
+}
+
+// Node returns the most recent [Node] read by Scan.
+func (s *Scanner) Node() Node {
+	return s.node
+}
+
+// Err returns the first error found by the [Scanner], or nil if the reader ended cleanly.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+
+	return s.err
+}
+
+func (s *Scanner) readNode() (Node, error) {
+	if err := s.decoder.checkNodeCount(s.count.getDecoded(), s.offset, nil); err != nil {
+		return Node{}, err
+	}
+
+	header, tag, length, err := s.readHeader()
+	if err != nil {
+		return Node{}, s.checkTruncatedHeader(err)
+	}
+
+	buf, err := s.readValue(header, tag, length)
+	if err != nil {
+		return Node{}, err
+	}
+
+	nodes, err := s.decoder.decodeBytes(buf.Bytes(), s.offset, nil, s.count)
+	if err != nil {
+		return Node{}, err
+	}
+
+	if err = s.checker.check(&nodes[0]); err != nil {
+		return Node{}, err
+	}
+
+	s.offset += uint64(buf.Len())
+	s.decoded++
+
+	return nodes[0], nil
+}
+
+// readValue reads the value after the header, returning a buffer with the whole encoded node.
+func (s *Scanner) readValue(header []byte, tag Tag, length uint64) (*bytes.Buffer, error) {
+	if length > math.MaxInt64-uint64(len(header)) {
+		return nil, s.newLengthMismatchError(tag, length, 0)
+	}
+
+	reserved := scannerChunkSize
+	if length < scannerChunkSize {
+		reserved = int(length)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(header)+reserved))
+	buf.Write(header)
+
+	if _, err := io.CopyN(buf, s.reader, int64(length)); err != nil {
+		if err == io.EOF {
+			err = s.newLengthMismatchError(tag, length, buf.Len()-len(header))
+			return nil, s.checker.checkTrailing(err, s.decoded, uint64(buf.Len()))
+		}
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// checkTruncatedHeader reports a header cut short by the end of the reader as trailing data when strict.
+func (s *Scanner) checkTruncatedHeader(err error) error {
+	var shortErr *MessageTooShortError
+	if !errors.As(err, &shortErr) {
+		return err
+	}
+
+	return s.checker.checkTrailing(err, s.decoded, shortErr.Available)
+}
+
+// checkLimits validates the header against the decoder limits as soon as it is read, before the value.
+func (s *Scanner) checkLimits(tag Tag, length uint64, headerSize int) error {
+	if err := s.decoder.checkValueLength(tag, length, s.offset, nil); err != nil {
+		return err
+	}
+
+	return s.decoder.checkReaderSize(s.offset+uint64(headerSize), length)
+}
+
+// readHeader reads the smallest possible header and then one byte at a time until it is complete,
+// checking the limits as soon as the length is known.
+func (s *Scanner) readHeader() (header []byte, tag Tag, length uint64, err error) {
+	header = make([]byte, s.decoder.header.minSize())
+	if n, err := io.ReadFull(s.reader, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, 0, 0, s.decoder.newHeaderError(errIncompleteHeader, uint64(n), s.offset, nil)
+		}
+		return nil, 0, 0, err
+	}
+
+	for {
+		if tag, length, _, err = s.decoder.header.read(header); err != errIncompleteHeader {
+			if err != nil {
+				return nil, 0, 0, s.decoder.newHeaderError(err, uint64(len(header)), s.offset, nil)
+			}
+			return header, tag, length, s.checkLimits(tag, length, len(header))
+		}
+
+		if header, err = s.readHeaderByte(header); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+}
+
+// readHeaderByte appends the next byte of an incomplete header, without reading beyond MaxReaderSize.
+func (s *Scanner) readHeaderByte(header []byte) ([]byte, error) {
+	if err := s.decoder.checkReaderSize(s.offset, uint64(len(header))+1); err != nil {
+		return nil, err
+	}
+
+	next := make([]byte, 1)
+	if _, err := io.ReadFull(s.reader, next); err != nil {
+		if err == io.EOF {
+			return nil, s.decoder.newHeaderError(errIncompleteHeader, uint64(len(header)), s.offset, nil)
+		}
+		return nil, err
+	}
+
+	return append(header, next[0]), nil
+}
+
+func (s *Scanner) newLengthMismatchError(tag Tag, length uint64, available int) error {
+	return &LengthMismatchError{
+		Offset:    s.offset,
+		Tag:       tag,
+		Length:    Length(length),
+		Available: uint64(available),
+		schema:    s.decoder.schema,
+	}
+}
@@ -0,0 +1,62 @@
+package tlv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_Scan(t *testing.T) {
+	reader := bytes.NewReader(append(append([]byte{}, data...), data...))
+	scanner := NewScanner(reader)
+
+	var nodes Nodes
+	for scanner.Scan() {
+		nodes = append(nodes, scanner.Node())
+	}
+
+	require.Nil(t, scanner.Err())
+	require.Equal(t, 2, len(nodes))
+	require.Equal(t, tagMessage, nodes[1].Tag)
+	require.Equal(t, Length(71), nodes[1].Length)
+	require.Equal(t, data, nodes[1].Raw)
+
+	children, err := nodes[1].GetNodes()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(children))
+}
+
+func TestScanner_Scan_WhenTheReaderIsEmpty(t *testing.T) {
+	scanner := NewScanner(bytes.NewReader(nil))
+
+	require.False(t, scanner.Scan())
+	require.Nil(t, scanner.Err())
+	require.Empty(t, scanner.Node())
+}
+
+func TestScanner_Scan_WhenTheHeaderIsTruncated(t *testing.T) {
+	scanner := NewScanner(bytes.NewReader(append(append([]byte{}, data...), 0x00, 0x01, 0x00)))
+
+	require.True(t, scanner.Scan())
+	require.False(t, scanner.Scan())
+	require.NotNil(t, scanner.Err())
+	require.Contains(t, scanner.Err().Error(), "too short")
+}
+
+func TestScanner_Scan_WhenTheValueIsTruncated(t *testing.T) {
+	scanner := NewScanner(bytes.NewReader(data[:len(data)-5]))
+
+	require.False(t, scanner.Scan())
+	require.NotNil(t, scanner.Err())
+	require.Contains(t, scanner.Err().Error(), "expected 71 bytes but only 66")
+	require.False(t, scanner.Scan())
+}
+
+func TestScanner_Scan_WhenTheReaderFails(t *testing.T) {
+	scanner := NewScanner(new(failingReader))
+
+	require.False(t, scanner.Scan())
+	require.NotNil(t, scanner.Err())
+	require.Contains(t, scanner.Err().Error(), "forcing reader error")
+}
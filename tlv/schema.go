@@ -0,0 +1,230 @@
+package tlv
+
+import (
+	"fmt"
+
+	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
+)
+
+// ValueType describes how the value of a tag is meant to be parsed.
+type ValueType uint8
+
+// Value types supported by [TagDefinition].
+const (
+	TypeBytes  ValueType = iota // Opaque bytes, no type validation.
+	TypeBool                    // Parsed with [Node.GetBool].
+	TypeUint                    // Parsed with the padded unsigned integer getters (up to 8 bytes).
+	TypeInt                     // Parsed with the padded signed integer getters (up to 8 bytes).
+	TypeFloat                   // Parsed with [Node.GetFloat32] or [Node.GetFloat64] (4 or 8 bytes).
+	TypeString                  // Parsed with [Node.GetString].
+	TypeDate                    // Parsed with [Node.GetDate] (up to 8 bytes).
+	TypeNested                  // Parsed with [Node.GetNodes].
+)
+
+var valueTypeNames = map[ValueType]string{
+	TypeBytes:  "bytes",
+	TypeBool:   "bool",
+	TypeUint:   "uint",
+	TypeInt:    "int",
+	TypeFloat:  "float",
+	TypeString: "string",
+	TypeDate:   "date",
+	TypeNested: "nested",
+}
+
+// String returns the name of the value type.
+func (t ValueType) String() string {
+	if name, ok := valueTypeNames[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("ValueType(%d)", uint8(t))
+}
+
+// TagDefinition describes the meaning and constraints of a tag in a [Schema].
+type TagDefinition struct {
+	Tag       Tag       // Tag being described.
+	Name      string    // Human-readable name used in errors and String output.
+	Type      ValueType // Expected value type.
+	MinLength Length    // Minimum value length in bytes.
+	MaxLength Length    // Maximum value length in bytes (0 means unlimited).
+	Children  []Tag     // Tags allowed in nested values (empty means any tag).
+}
+
+// Schema is a registry of [TagDefinition] used to validate messages, name tags and identify
+// which values hold nested TLV. Tags without a definition are accepted as opaque bytes.
+// A schema must not be changed after being given to a [Decoder].
+type Schema struct {
+	definitions map[Tag]TagDefinition
+}
+
+// CreateSchema creates a [Schema] with the given definitions.
+func CreateSchema(definitions ...TagDefinition) (*Schema, error) {
+	res := &Schema{definitions: make(map[Tag]TagDefinition, len(definitions))}
+
+	for _, definition := range definitions {
+		if err := res.Register(definition); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// MustCreateSchema creates a [Schema] with the given definitions or panics in case of any errors.
+func MustCreateSchema(definitions ...TagDefinition) *Schema {
+	res, err := CreateSchema(definitions...)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// Register adds a definition to the schema, failing if the tag is already defined.
+func (s *Schema) Register(definition TagDefinition) error {
+	if _, exists := s.definitions[definition.Tag]; exists {
+		return &DuplicateDefinitionError{Tag: definition.Tag}
+	}
+
+	s.definitions[definition.Tag] = definition
+	return nil
+}
+
+// Lookup returns the definition of a tag, if any.
+func (s *Schema) Lookup(tag Tag) (res TagDefinition, ok bool) {
+	if s == nil {
+		return res, false
+	}
+
+	res, ok = s.definitions[tag]
+	return res, ok
+}
+
+// GetName returns the name of a tag, or an empty string if it has no definition.
+func (s *Schema) GetName(tag Tag) string {
+	definition, _ := s.Lookup(tag)
+	return definition.Name
+}
+
+// IsNested returns whether the tag is defined as holding nested TLV.
+func (s *Schema) IsNested(tag Tag) bool {
+	definition, ok := s.Lookup(tag)
+	return ok && definition.Type == TypeNested
+}
+
+// Validate checks the nodes and all their nested values against the schema definitions.
+func (s *Schema) Validate(nodes Nodes) error {
+	return s.validate(nodes, nil, true)
+}
+
+// validate checks sibling nodes, restricting their tags to the children allowed by the parent.
+// Nested values are only decoded and checked when deep is set, as decoders validate each level
+// when it is decoded.
+func (s *Schema) validate(nodes Nodes, parent *TagDefinition, deep bool) error {
+	for i := range nodes {
+		if err := s.validateNode(&nodes[i], parent, deep); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateNode(node *Node, parent *TagDefinition, deep bool) error {
+	if parent != nil && !parent.allowsChild(node.Tag) {
+		return s.newSchemaError(node, fmt.Sprintf("tag is not allowed in %s", s.formatTag(parent.Tag)))
+	}
+
+	definition, ok := s.Lookup(node.Tag)
+	if !ok {
+		return nil
+	}
+
+	if reason := definition.checkLength(len(node.Value)); reason != "" {
+		return s.newSchemaError(node, reason)
+	}
+
+	if !deep || definition.Type != TypeNested || len(node.Value) == 0 {
+		return nil
+	}
+
+	children, err := node.decodeChildren()
+	if err != nil {
+		return err
+	}
+
+	return s.validate(children, &definition, true)
+}
+
+func (s *Schema) newSchemaError(node *Node, reason string) error {
+	return &SchemaError{
+		Offset: node.offset,
+		Tag:    node.Tag,
+		Reason: reason,
+		Path:   copyPath(node.path),
+		schema: s,
+	}
+}
+
+// formatTag formats the tag as hexadecimal, followed by its name if defined.
+func (s *Schema) formatTag(tag Tag) string {
+	if name := s.GetName(tag); name != "" {
+		return fmt.Sprintf("0x%x (%s)", uint64(tag), name)
+	}
+
+	return fmt.Sprintf("0x%x", uint64(tag))
+}
+
+// formatPathTag formats a path element with its name if defined, or as hexadecimal.
+func (s *Schema) formatPathTag(tag Tag) string {
+	if name := s.GetName(tag); name != "" {
+		return name
+	}
+
+	return fmt.Sprintf("0x%x", uint64(tag))
+}
+
+func (d *TagDefinition) allowsChild(tag Tag) bool {
+	if len(d.Children) == 0 {
+		return true
+	}
+
+	for _, child := range d.Children {
+		if child == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkLength returns the reason why a value length is invalid, or an empty string.
+func (d *TagDefinition) checkLength(length int) string {
+	if uint64(length) < uint64(d.MinLength) {
+		return fmt.Sprintf("length %d is below the minimum of %d", length, d.MinLength)
+	}
+
+	if d.MaxLength > 0 && uint64(length) > uint64(d.MaxLength) {
+		return fmt.Sprintf("length %d is above the maximum of %d", length, d.MaxLength)
+	}
+
+	if !d.Type.allowsLength(length) {
+		return fmt.Sprintf("length %d is invalid for %s values", length, d.Type)
+	}
+
+	return ""
+}
+
+func (t ValueType) allowsLength(length int) bool {
+	switch t {
+	case TypeBool:
+		return length >= sizes.Bool
+	case TypeUint, TypeInt, TypeDate:
+		return length >= 1 && length <= sizes.Uint64
+	case TypeFloat:
+		return length == sizes.Float32 || length == sizes.Float64
+	default:
+		return true
+	}
+}
@@ -0,0 +1,157 @@
+package tlv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = MustCreateSchema(
+	TagDefinition{Tag: tagMessage, Name: "message", Type: TypeNested, Children: []Tag{tagPushNotification}},
+	TagDefinition{
+		Tag:      tagPushNotification,
+		Name:     "push_notification",
+		Type:     TypeNested,
+		Children: []Tag{tagTitle, tagActionID, tagTimestamp, tagSilent},
+	},
+	TagDefinition{Tag: tagTitle, Name: "title", Type: TypeString, MinLength: 1, MaxLength: 32},
+	TagDefinition{Tag: tagActionID, Name: "action_id", Type: TypeUint},
+	TagDefinition{Tag: tagTimestamp, Name: "timestamp", Type: TypeDate},
+	TagDefinition{Tag: tagSilent, Name: "silent", Type: TypeBool},
+)
+
+func TestCreateSchema_WhenATagIsDuplicated(t *testing.T) {
+	schema, err := CreateSchema(TagDefinition{Tag: 0x01}, TagDefinition{Tag: 0x01})
+
+	require.Nil(t, schema)
+	require.True(t, errors.Is(err, ErrDuplicateTag))
+}
+
+func TestMustCreateSchema_WhenATagIsDuplicated(t *testing.T) {
+	defer func() {
+		require.NotNil(t, recover())
+	}()
+
+	_ = MustCreateSchema(TagDefinition{Tag: 0x01}, TagDefinition{Tag: 0x01})
+}
+
+func TestSchema_Lookup(t *testing.T) {
+	definition, ok := testSchema.Lookup(tagTitle)
+
+	require.True(t, ok)
+	require.Equal(t, "title", definition.Name)
+	require.Equal(t, "title", testSchema.GetName(tagTitle))
+	require.Empty(t, testSchema.GetName(0xffff))
+	require.True(t, testSchema.IsNested(tagMessage))
+	require.False(t, testSchema.IsNested(tagTitle))
+
+	var nilSchema *Schema
+	_, ok = nilSchema.Lookup(tagTitle)
+	require.False(t, ok)
+}
+
+func TestSchema_Validate(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	require.Nil(t, testSchema.Validate(nodes))
+}
+
+func TestSchema_Validate_WhenALengthIsInvalid(t *testing.T) {
+	scenarios := map[string]Node{
+		"length 0 is below the minimum of 1":   NewNode(tagTitle, nil),
+		"length 33 is above the maximum of 32": NewNode(tagTitle, make([]byte, 33)),
+		"length 9 is invalid for uint values":  NewNode(tagActionID, make([]byte, 9)),
+		"length 0 is invalid for bool values":  NewNode(tagSilent, nil),
+	}
+
+	for reason, node := range scenarios {
+		err := testSchema.Validate(Nodes{node})
+
+		var schemaErr *SchemaError
+		require.True(t, errors.As(err, &schemaErr))
+		require.Equal(t, reason, schemaErr.Reason)
+		require.Equal(t, node.Tag, schemaErr.Tag)
+	}
+}
+
+func TestSchema_Validate_WhenAChildIsNotAllowed(t *testing.T) {
+	value, err := EncodeNodes(Nodes{NewNode(tagTitle, []byte("title"))})
+	require.Nil(t, err)
+
+	err = testSchema.Validate(Nodes{NewNode(tagMessage, value)})
+
+	require.True(t, errors.Is(err, ErrSchemaViolation))
+	require.Contains(t, err.Error(), "tag 0x102 (title), tag is not allowed in 0x1 (message) at offset 0 under message")
+}
+
+func TestSchema_Validate_WhenANestedValueIsInvalid(t *testing.T) {
+	err := testSchema.Validate(Nodes{NewNode(tagMessage, []byte{0x01})})
+
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+}
+
+func TestDecoder_WithSchema(t *testing.T) {
+	d := stdDecoder.WithSchema(testSchema)
+
+	nodes, err := d.DecodeBytes(data)
+
+	require.Nil(t, err)
+	require.Equal(t, testSchema, d.GetSchema())
+	require.Nil(t, stdDecoder.GetSchema())
+	require.Equal(t, "message", nodes[0].GetName())
+	require.True(t, nodes[0].IsNested())
+	require.True(t, strings.HasPrefix(nodes[0].String(), "message: "))
+
+	items, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	fields, err := items[1].GetNodes()
+	require.Nil(t, err)
+	require.Equal(t, "title", fields[0].GetName())
+	require.False(t, fields[0].IsNested())
+}
+
+func TestDecoder_WithSchema_WhenTheMessageIsInvalid(t *testing.T) {
+	corrupted := append([]byte{}, data...)
+	corrupted[57] = 0x02 // second action_id tag becomes 0x0203, which is not allowed
+
+	nodes, err := stdDecoder.WithSchema(testSchema).DecodeBytes(corrupted)
+	require.Nil(t, err)
+	require.NotNil(t, testSchema.Validate(nodes))
+
+	children, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	_, err = children[1].GetNodes()
+
+	var schemaErr *SchemaError
+	require.True(t, errors.As(err, &schemaErr))
+	require.Equal(t, uint64(57), schemaErr.Offset)
+	require.Equal(t, []Tag{tagMessage, tagPushNotification}, schemaErr.Path)
+	require.Contains(t, err.Error(), "under message/push_notification")
+}
+
+func TestDecoder_WithSchema_NamesTagsInDecodingErrors(t *testing.T) {
+	corrupted := append([]byte{}, data...)
+	corrupted[11] = 0xff // title length
+
+	nodes, err := stdDecoder.WithSchema(testSchema).DecodeBytes(corrupted)
+	require.Nil(t, err)
+
+	children, err := nodes[0].GetNodes()
+	require.Nil(t, err)
+
+	_, err = children[0].GetNodes()
+
+	require.True(t, errors.Is(err, ErrLengthMismatch))
+	require.Contains(t, err.Error(), "for tag 0x102 (title)")
+	require.Contains(t, err.Error(), "under message/push_notification")
+}
+
+func TestValueType_String(t *testing.T) {
+	require.Equal(t, "nested", TypeNested.String())
+	require.Equal(t, "ValueType(99)", ValueType(99).String())
+}
@@ -3,6 +3,7 @@ package tlv
 import (
 	"encoding/binary"
 	"io"
+	"time"
 
 	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
 )
@@ -11,13 +12,21 @@ import (
 var stdByteOrder = binary.BigEndian
 
 // stdDecoder uses 2 bytes for tags and lengths and parses them as big endian.
-var stdDecoder = MustCreateDecoder(sizes.Uint16, sizes.Uint16, stdByteOrder)
+var stdDecoder = newFixedDecoder(sizes.Uint16, sizes.Uint16, stdByteOrder)
+
+// stdEncoder shares the configuration of the standard decoder.
+var stdEncoder = stdDecoder.encoder
 
 // DecodeReader decodes the entire [io.Reader] data as a list of TLV nodes.
 func DecodeReader(reader io.Reader) (Nodes, error) {
 	return stdDecoder.DecodeReader(reader)
 }
 
+// NewScanner creates a [Scanner] that decodes TLV [Nodes] from the reader one at a time.
+func NewScanner(reader io.Reader) *Scanner {
+	return stdDecoder.NewScanner(reader)
+}
+
 // DecodeBytes decodes a byte array as a list of TLV [Nodes].
 func DecodeBytes(data []byte) (Nodes, error) {
 	return stdDecoder.DecodeBytes(data)
@@ -32,3 +41,107 @@ func DecodeSingle(data []byte) (res Node, read uint64, err error) {
 func NewNode(tag Tag, value []byte) Node {
 	return stdDecoder.NewNode(tag, value)
 }
+
+// NewBoolNode creates a [Node] with a boolean value and the default [Decoder] configuration.
+func NewBoolNode(tag Tag, value bool) Node {
+	return stdDecoder.NewBoolNode(tag, value)
+}
+
+// NewUint8Node creates a [Node] with an uint8 value and the default [Decoder] configuration.
+func NewUint8Node(tag Tag, value uint8) Node {
+	return stdDecoder.NewUint8Node(tag, value)
+}
+
+// NewUint16Node creates a [Node] with a big endian uint16 value and the default [Decoder] configuration.
+func NewUint16Node(tag Tag, value uint16, options ...ValueOption) Node {
+	return stdDecoder.NewUint16Node(tag, value, options...)
+}
+
+// NewUint32Node creates a [Node] with a big endian uint32 value and the default [Decoder] configuration.
+func NewUint32Node(tag Tag, value uint32, options ...ValueOption) Node {
+	return stdDecoder.NewUint32Node(tag, value, options...)
+}
+
+// NewUint64Node creates a [Node] with a big endian uint64 value and the default [Decoder] configuration.
+func NewUint64Node(tag Tag, value uint64, options ...ValueOption) Node {
+	return stdDecoder.NewUint64Node(tag, value, options...)
+}
+
+// NewStringNode creates a [Node] with a UTF-8 text value and the default [Decoder] configuration.
+func NewStringNode(tag Tag, value string) Node {
+	return stdDecoder.NewStringNode(tag, value)
+}
+
+// NewDateNode creates a [Node] with a date value as Unix seconds and the default [Decoder] configuration.
+func NewDateNode(tag Tag, value time.Time, options ...ValueOption) Node {
+	return stdDecoder.NewDateNode(tag, value, options...)
+}
+
+// NewNestedNode creates a [Node] holding the encoded children with the default [Decoder] configuration.
+func NewNestedNode(tag Tag, children ...Node) (Node, error) {
+	return stdDecoder.NewNestedNode(tag, children...)
+}
+
+// NewBuilder creates a [Builder] for messages with the default [Decoder] configuration.
+func NewBuilder() *Builder {
+	return stdDecoder.NewBuilder()
+}
+
+// EncodeNode encodes a single [Node] as TLV bytes with the default [Encoder] configuration.
+func EncodeNode(node Node) ([]byte, error) {
+	return stdEncoder.EncodeNode(node)
+}
+
+// EncodeNodes encodes a list of [Nodes] as TLV bytes with the default [Encoder] configuration.
+func EncodeNodes(nodes Nodes) ([]byte, error) {
+	return stdEncoder.EncodeNodes(nodes)
+}
+
+// WriteNodes encodes a list of [Nodes] to an [io.Writer] with the default [Encoder] configuration.
+func WriteNodes(writer io.Writer, nodes Nodes) (written uint64, err error) {
+	return stdEncoder.WriteNodes(writer, nodes)
+}
+
+// Marshal encodes a struct annotated with `tlv` struct tags with the default [Encoder] configuration.
+func Marshal(v interface{}) ([]byte, error) {
+	return stdEncoder.Marshal(v)
+}
+
+// Unmarshal decodes TLV bytes into a struct annotated with `tlv` struct tags
+// with the default [Decoder] configuration.
+func Unmarshal(data []byte, v interface{}) error {
+	return stdDecoder.Unmarshal(data, v)
+}
+
+// FromTree encodes trees with the default [Decoder] configuration and decodes them back as TLV [Nodes].
+func FromTree(trees ...TreeNode) (Nodes, error) {
+	return stdDecoder.FromTree(trees...)
+}
+
+// DecodeJSON parses a JSON list of trees and rebuilds it as TLV [Nodes] with the default [Decoder] configuration.
+func DecodeJSON(data []byte) (Nodes, error) {
+	return stdDecoder.DecodeJSON(data)
+}
+
+// DecodePartial decodes a byte array as many TLV [Nodes] as possible with the default [Decoder] configuration,
+// reporting corrupted nodes in a *PartialDecodeError.
+func DecodePartial(data []byte, recovery Recovery) (Nodes, error) {
+	return stdDecoder.DecodePartial(data, recovery)
+}
+
+// DecodeBytesInto decodes a byte array as TLV [Nodes] appended to dst with the default [Decoder] configuration.
+func DecodeBytesInto(dst Nodes, data []byte) (Nodes, error) {
+	return stdDecoder.DecodeBytesInto(dst, data)
+}
+
+// All returns an iterator that decodes the nodes in a byte array one at a time
+// with the default [Decoder] configuration.
+func All(data []byte) func(yield func(Node, error) bool) {
+	return stdDecoder.All(data)
+}
+
+// DecodeIndexed decodes a byte array as TLV [Nodes] wrapped in an [IndexedNodes] view
+// with the default [Decoder] configuration.
+func DecodeIndexed(data []byte) (*IndexedNodes, error) {
+	return stdDecoder.DecodeIndexed(data)
+}
@@ -0,0 +1,98 @@
+package tlv
+
+import "errors"
+
+// strictMode holds the validations enabled by the WithStrict options.
+type strictMode struct {
+	trailingData  bool
+	duplicateTags bool
+	zeroTags      bool
+	emptyValues   map[Tag]struct{}
+}
+
+// strictChecker validates sibling nodes one at a time, keeping the tags already seen among them.
+type strictChecker struct {
+	decoder *decoder
+	seen    map[Tag]uint64
+}
+
+func (d *decoder) newStrictChecker() strictChecker {
+	return strictChecker{decoder: d}
+}
+
+// check validates the next sibling node against the strict mode.
+func (c *strictChecker) check(node *Node) error {
+	strict := &c.decoder.strict
+
+	if strict.zeroTags && node.Tag == 0 {
+		return &ZeroTagError{Offset: node.offset, Path: copyPath(node.path), schema: c.decoder.schema}
+	}
+
+	if _, ok := strict.emptyValues[node.Tag]; ok && len(node.Value) == 0 {
+		return &EmptyValueError{Offset: node.offset, Tag: node.Tag, Path: copyPath(node.path), schema: c.decoder.schema}
+	}
+
+	if strict.duplicateTags {
+		return c.checkDuplicate(node)
+	}
+
+	return nil
+}
+
+func (c *strictChecker) checkDuplicate(node *Node) error {
+	if first, ok := c.seen[node.Tag]; ok {
+		return &DuplicateNodeError{
+			Offset:      node.offset,
+			FirstOffset: first,
+			Tag:         node.Tag,
+			Path:        copyPath(node.path),
+			schema:      c.decoder.schema,
+		}
+	}
+
+	if c.seen == nil {
+		c.seen = make(map[Tag]uint64)
+	}
+
+	c.seen[node.Tag] = node.offset
+	return nil
+}
+
+// checkTrailing converts an error for a truncated node (either its header or its value) after at least one
+// sibling node into a [TrailingDataError].
+func (c *strictChecker) checkTrailing(err error, decoded int, remaining uint64) error {
+	if !c.decoder.strict.trailingData || decoded == 0 {
+		return err
+	}
+
+	var shortErr *MessageTooShortError
+	if errors.As(err, &shortErr) {
+		return &TrailingDataError{Offset: shortErr.Offset, Size: remaining, Path: shortErr.Path, schema: c.decoder.schema}
+	}
+
+	var mismatchErr *LengthMismatchError
+	if errors.As(err, &mismatchErr) {
+		return &TrailingDataError{
+			Offset: mismatchErr.Offset,
+			Size:   remaining,
+			Path:   mismatchErr.Path,
+			schema: c.decoder.schema,
+		}
+	}
+
+	return err
+}
+
+// checkSingle fails if a node decoded by DecodeSingle is not valid in strict mode.
+func (d *decoder) checkSingle(node *Node, read uint64, size int) error {
+	checker := d.newStrictChecker()
+	if err := checker.check(node); err != nil {
+		return err
+	}
+
+	if d.strict.trailingData && read < uint64(size) {
+		return &TrailingDataError{Offset: read, Size: uint64(size) - read, schema: d.schema}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package tlv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_DecodeSingle_WithStrictTrailingData(t *testing.T) {
+	d := MustCreateDecoderWithOptions(WithStrictTrailingData())
+
+	_, _, err := d.DecodeSingle(append(append([]byte{}, data...), 0x00, 0x01))
+
+	var trailingErr *TrailingDataError
+	require.True(t, errors.As(err, &trailingErr))
+	require.Equal(t, uint64(len(data)), trailingErr.Offset)
+	require.Equal(t, uint64(2), trailingErr.Size)
+
+	_, read, err := d.DecodeSingle(data)
+	require.Nil(t, err)
+	require.Equal(t, uint64(len(data)), read)
+}
+
+func TestDecoder_DecodeBytes_WithStrictTrailingData(t *testing.T) {
+	input := append(append([]byte{}, data...), 0x00, 0x01)
+
+	_, err := DecodeBytes(input)
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+
+	_, err = MustCreateDecoderWithOptions(WithStrictTrailingData()).DecodeBytes(input)
+	require.True(t, errors.Is(err, ErrTrailingData))
+
+	_, err = MustCreateDecoderWithOptions(WithStrictTrailingData()).DecodeBytes(input[len(data):])
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+}
+
+func TestDecoder_DecodeBytes_WithStrictTrailingDataWhenTheValueIsTruncated(t *testing.T) {
+	input := append(append([]byte{}, data...), 0x00, 0x01, 0x00, 0x05, 0xaa)
+
+	_, err := DecodeBytes(input)
+	require.True(t, errors.Is(err, ErrLengthMismatch))
+
+	_, err = MustCreateDecoderWithOptions(WithStrictTrailingData()).DecodeBytes(input)
+
+	var trailingErr *TrailingDataError
+	require.True(t, errors.As(err, &trailingErr))
+	require.Equal(t, uint64(len(data)), trailingErr.Offset)
+	require.Equal(t, uint64(5), trailingErr.Size)
+}
+
+func TestDecoder_DecodeBytes_WithStrictEmptyValues(t *testing.T) {
+	input, err := EncodeNodes(Nodes{NewNode(0x01, nil), NewNode(0x02, []byte{0x01}), NewNode(0x03, nil)})
+	require.Nil(t, err)
+
+	_, err = MustCreateDecoderWithOptions(WithStrictEmptyValues(0x02)).DecodeBytes(input)
+	require.Nil(t, err)
+
+	_, err = MustCreateDecoderWithOptions(WithStrictEmptyValues(0x02), WithStrictEmptyValues(0x03)).DecodeBytes(input)
+
+	var emptyErr *EmptyValueError
+	require.True(t, errors.As(err, &emptyErr))
+	require.Equal(t, Tag(0x03), emptyErr.Tag)
+	require.Equal(t, uint64(9), emptyErr.Offset)
+}
+
+func TestDecoder_DecodeBytes_WithStrictDuplicateTags(t *testing.T) {
+	d := MustCreateDecoderWithOptions(WithStrictDuplicateTags())
+
+	nodes, err := d.DecodeBytes(data)
+	require.Nil(t, err)
+
+	_, err = nodes[0].GetNodes()
+
+	var duplicateErr *DuplicateNodeError
+	require.True(t, errors.As(err, &duplicateErr))
+	require.Equal(t, tagPushNotification, duplicateErr.Tag)
+	require.Equal(t, uint64(4), duplicateErr.FirstOffset)
+	require.Equal(t, uint64(39), duplicateErr.Offset)
+	require.Equal(t, []Tag{tagMessage}, duplicateErr.Path)
+}
+
+func TestDecoder_DecodeBytes_WithStrictZeroTags(t *testing.T) {
+	input := append(append([]byte{}, data...), 0x00, 0x00, 0x00, 0x00)
+
+	nodes, err := DecodeBytes(input)
+	require.Nil(t, err)
+	require.Len(t, nodes, 2)
+
+	_, err = MustCreateDecoderWithOptions(WithStrictZeroTags()).DecodeBytes(input)
+
+	var zeroErr *ZeroTagError
+	require.True(t, errors.As(err, &zeroErr))
+	require.Equal(t, uint64(len(data)), zeroErr.Offset)
+}
+
+func TestScanner_Scan_WithStrictDuplicateTags(t *testing.T) {
+	input := append(append([]byte{}, data...), data...)
+	scanner := MustCreateDecoderWithOptions(WithStrictDuplicateTags()).NewScanner(bytes.NewReader(input))
+
+	require.True(t, scanner.Scan())
+	require.False(t, scanner.Scan())
+
+	var duplicateErr *DuplicateNodeError
+	require.True(t, errors.As(scanner.Err(), &duplicateErr))
+	require.Equal(t, uint64(len(data)), duplicateErr.Offset)
+}
+
+func TestScanner_Scan_WithStrictTrailingData(t *testing.T) {
+	for _, trailing := range [][]byte{{0x00, 0x01, 0x00}, {0x00, 0x01, 0x00, 0x05, 0xaa}} {
+		input := append(append([]byte{}, data...), trailing...)
+
+		scanner := stdDecoder.NewScanner(bytes.NewReader(input))
+		require.True(t, scanner.Scan())
+		require.False(t, scanner.Scan())
+		require.False(t, errors.Is(scanner.Err(), ErrTrailingData))
+
+		scanner = MustCreateDecoderWithOptions(WithStrictTrailingData()).NewScanner(bytes.NewReader(input))
+		require.True(t, scanner.Scan())
+		require.False(t, scanner.Scan())
+
+		var trailingErr *TrailingDataError
+		require.True(t, errors.As(scanner.Err(), &trailingErr))
+		require.Equal(t, uint64(len(data)), trailingErr.Offset)
+		require.Equal(t, uint64(len(trailing)), trailingErr.Size)
+	}
+
+	scanner := MustCreateDecoderWithOptions(WithStrictTrailingData()).NewScanner(bytes.NewReader([]byte{0x00, 0x01}))
+	require.False(t, scanner.Scan())
+	require.True(t, errors.Is(scanner.Err(), ErrMessageTooShort))
+}
+
+func TestDecoder_DecodePartial_WithStrictZeroTags(t *testing.T) {
+	input := append([]byte{0x00, 0x00, 0x00, 0x00}, data...)
+
+	nodes, err := MustCreateDecoderWithOptions(WithStrictZeroTags()).DecodePartial(input, RecoveryStop)
+
+	require.Len(t, nodes, 1)
+
+	var partialErr *PartialDecodeError
+	require.True(t, errors.As(err, &partialErr))
+	require.True(t, errors.Is(partialErr.Failures[0].Err, ErrZeroTag))
+}
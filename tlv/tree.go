@@ -0,0 +1,164 @@
+package tlv
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// TreeNode is the JSON and YAML representation of a [Node]. Values that decode as TLV are
+// represented by their children, any other value is represented as hexadecimal.
+type TreeNode struct {
+	Tag      string     `json:"tag" yaml:"tag"`                               // Hexadecimal tag, e.g. "0x102".
+	Name     string     `json:"name,omitempty" yaml:"name,omitempty"`         // Schema name, ignored when importing.
+	Length   Length     `json:"length" yaml:"length"`                         // Ignored when importing.
+	Value    string     `json:"value,omitempty" yaml:"value,omitempty"`       // Hexadecimal value of leaf nodes.
+	Children []TreeNode `json:"children,omitempty" yaml:"children,omitempty"` // Nested nodes.
+}
+
+// ToTree converts the node and its nested values to a [TreeNode].
+func (n *Node) ToTree() TreeNode {
+	res := TreeNode{
+		Tag:    fmt.Sprintf("0x%x", uint64(n.Tag)),
+		Name:   n.GetName(),
+		Length: n.Length,
+	}
+
+	if children, ok, _ := n.decodeNested(); ok {
+		res.Children = children.ToTree()
+	} else {
+		res.Value = hex.EncodeToString(n.Value)
+	}
+
+	return res
+}
+
+// ToTree converts the nodes and their nested values to a list of [TreeNode].
+func (ns Nodes) ToTree() []TreeNode {
+	res := make([]TreeNode, len(ns))
+	for i := range ns {
+		res[i] = ns[i].ToTree()
+	}
+
+	return res
+}
+
+// MarshalJSON implements [json.Marshaler] using the [TreeNode] representation.
+func (n Node) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.ToTree())
+}
+
+// MarshalJSON implements [json.Marshaler] using the [TreeNode] representation.
+func (ns Nodes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ns.ToTree())
+}
+
+// MarshalYAML implements the gopkg.in/yaml.v3 Marshaler interface using the [TreeNode] representation.
+func (n Node) MarshalYAML() (interface{}, error) {
+	return n.ToTree(), nil
+}
+
+// MarshalYAML implements the gopkg.in/yaml.v3 Marshaler interface using the [TreeNode] representation.
+func (ns Nodes) MarshalYAML() (interface{}, error) {
+	return ns.ToTree(), nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler] using the [TreeNode] representation.
+// The node is rebuilt with its current decoder configuration, or the standard one.
+func (n *Node) UnmarshalJSON(data []byte) error {
+	var tree TreeNode
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+
+	nodes, err := n.getSafeDecoder().FromTree(tree)
+	if err != nil {
+		return err
+	}
+
+	*n = nodes[0]
+	return nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler] using the [TreeNode] representation.
+// Nodes are rebuilt with the standard decoder configuration, use [Decoder.DecodeJSON] for others.
+func (ns *Nodes) UnmarshalJSON(data []byte) error {
+	nodes, err := stdDecoder.DecodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*ns = nodes
+	return nil
+}
+
+// FromTree encodes the trees with the decoder configuration and decodes them back as [Nodes].
+// Lengths are computed from the values and children, so edited trees do not need to update them.
+func (d *decoder) FromTree(trees ...TreeNode) (Nodes, error) {
+	if len(trees) == 0 {
+		return nil, nil
+	}
+
+	var data []byte
+	for i := range trees {
+		var err error
+		if data, err = d.appendTree(data, &trees[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.decodeBytes(data, 0, nil, nil)
+}
+
+// DecodeJSON parses a JSON list of [TreeNode] and rebuilds it with the decoder configuration.
+func (d *decoder) DecodeJSON(data []byte) (Nodes, error) {
+	var trees []TreeNode
+	if err := json.Unmarshal(data, &trees); err != nil {
+		return nil, err
+	}
+
+	return d.FromTree(trees...)
+}
+
+func (d *decoder) appendTree(dst []byte, tree *TreeNode) ([]byte, error) {
+	tag, err := strconv.ParseUint(tree.Tag, 0, 64)
+	if err != nil {
+		return nil, &InvalidTreeError{Tag: tree.Tag, Reason: "tag is not a number"}
+	}
+
+	value, err := d.getTreeValue(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	if dst, err = d.encoder.header.append(dst, Tag(tag), uint64(len(value))); err != nil {
+		return nil, err
+	}
+
+	return append(dst, value...), nil
+}
+
+func (d *decoder) getTreeValue(tree *TreeNode) ([]byte, error) {
+	if len(tree.Children) == 0 {
+		value, err := hex.DecodeString(tree.Value)
+		if err != nil {
+			return nil, &InvalidTreeError{Tag: tree.Tag, Reason: "value is not hexadecimal"}
+		}
+		return value, nil
+	}
+
+	if tree.Value != "" {
+		return nil, &InvalidTreeError{Tag: tree.Tag, Reason: "value and children are mutually exclusive"}
+	}
+
+	var value []byte
+	for i := range tree.Children {
+		var err error
+		if value, err = d.appendTree(value, &tree.Children[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,180 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNodes_MarshalJSON(t *testing.T) {
+	expected := `[{"tag":"0x1","name":"message","length":71,"children":[` +
+		`{"tag":"0x101","name":"push_notification","length":31,"children":[` +
+		`{"tag":"0x102","name":"title","length":12,"value":"48656c6c6f20746865726521"},` +
+		`{"tag":"0x103","name":"action_id","length":3,"value":"bc614e"},` +
+		`{"tag":"0x104","name":"timestamp","length":4,"value":"60dc8f20"}]},` +
+		`{"tag":"0x101","name":"push_notification","length":32,"children":[` +
+		`{"tag":"0x102","name":"title","length":10,"value":"596f752074686572653f"},` +
+		`{"tag":"0x103","name":"action_id","length":1,"value":"f0"},` +
+		`{"tag":"0x105","name":"silent","length":1,"value":"01"},` +
+		`{"tag":"0x104","name":"timestamp","length":4,"value":"603c673b"}]}]}]`
+
+	nodes, err := stdDecoder.WithSchema(testSchema).DecodeBytes(data)
+	require.Nil(t, err)
+
+	res, err := json.Marshal(nodes)
+
+	require.Nil(t, err)
+	require.Equal(t, expected, string(res))
+}
+
+func TestNode_MarshalJSON_WhenTheNodeIsAValue(t *testing.T) {
+	type envelope struct {
+		Node Node `json:"node"`
+	}
+
+	res, err := json.Marshal(envelope{Node: NewNode(0x1, []byte{0xab})})
+
+	require.Nil(t, err)
+	require.Equal(t, `{"node":{"tag":"0x1","length":1,"value":"ab"}}`, string(res))
+}
+
+func TestNodes_UnmarshalJSON(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	exported, err := json.Marshal(nodes)
+	require.Nil(t, err)
+
+	var imported Nodes
+	err = json.Unmarshal(exported, &imported)
+	require.Nil(t, err)
+
+	encoded, err := EncodeNodes(imported)
+	require.Nil(t, err)
+	require.Equal(t, data, encoded)
+	require.Equal(t, nodes, imported)
+}
+
+func TestNode_UnmarshalJSON(t *testing.T) {
+	node := NewNode(0x0102, []byte("hi"))
+
+	exported, err := json.Marshal(&node)
+	require.Nil(t, err)
+	require.Equal(t, `{"tag":"0x102","length":2,"value":"6869"}`, string(exported))
+
+	var imported Node
+	err = json.Unmarshal(exported, &imported)
+
+	require.Nil(t, err)
+	require.Equal(t, node.Tag, imported.Tag)
+	require.Equal(t, node.Value, imported.Value)
+	require.Equal(t, []byte{0x01, 0x02, 0x00, 0x02, 'h', 'i'}, imported.Raw)
+}
+
+func TestNode_UnmarshalJSON_WhenTheTreeIsInvalid(t *testing.T) {
+	var node Node
+
+	require.NotNil(t, json.Unmarshal([]byte(`[]`), &node))
+	require.True(t, errors.Is(json.Unmarshal([]byte(`{"tag":"title"}`), &node), ErrInvalidTree))
+}
+
+func TestDecoder_DecodeJSON_RecomputesLengths(t *testing.T) {
+	input := `[{"tag":"0x21","length":0,"children":[{"tag":"0x9f02","length":0,"value":"6869"}]}]`
+
+	nodes, err := CreateBERDecoder().DecodeJSON([]byte(input))
+	require.Nil(t, err)
+
+	require.Equal(t, Length(5), nodes[0].Length)
+	require.Equal(t, []byte{0x21, 0x05, 0x9f, 0x02, 0x02, 'h', 'i'}, nodes[0].Raw)
+}
+
+func TestDecoder_FromTree_WithCustomConfiguration(t *testing.T) {
+	decoder := MustCreateDecoder(1, 4, binary.LittleEndian)
+	trees := []TreeNode{{Tag: "0x1", Children: []TreeNode{{Tag: "2", Value: "ff"}}}}
+
+	nodes, err := decoder.FromTree(trees...)
+	require.Nil(t, err)
+
+	expected := []byte{0x01, 0x06, 0x00, 0x00, 0x00, 0x02, 0x01, 0x00, 0x00, 0x00, 0xff}
+	require.Equal(t, expected, nodes[0].Raw)
+	require.Equal(t, binary.LittleEndian, nodes[0].getByteOrder())
+}
+
+func TestDecoder_FromTree_WhenTheTreeIsInvalid(t *testing.T) {
+	scenarios := map[string]TreeNode{
+		"tag is not a number":                       {Tag: "title"},
+		"value is not hexadecimal":                  {Tag: "0x1", Value: "zz"},
+		"value and children are mutually exclusive": {Tag: "0x1", Value: "00", Children: []TreeNode{{Tag: "0x2"}}},
+	}
+
+	for reason, tree := range scenarios {
+		_, err := FromTree(TreeNode{Tag: "0x1", Children: []TreeNode{tree}})
+
+		var treeErr *InvalidTreeError
+		require.True(t, errors.As(err, &treeErr))
+		require.Equal(t, reason, treeErr.Reason)
+	}
+}
+
+func TestDecoder_FromTree_WhenTheTagDoesNotFit(t *testing.T) {
+	_, err := FromTree(TreeNode{Tag: "0x10000"})
+
+	require.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestDecoder_FromTree_WhenThereAreNoTrees(t *testing.T) {
+	nodes, err := FromTree()
+
+	require.Nil(t, err)
+	require.Nil(t, nodes)
+}
+
+func TestDecodeJSON_WhenTheJSONIsInvalid(t *testing.T) {
+	_, err := DecodeJSON([]byte(`{`))
+
+	require.NotNil(t, err)
+}
+
+func TestNodes_ToTree_WithYAML(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	exported, err := yaml.Marshal(nodes.ToTree())
+	require.Nil(t, err)
+
+	var trees []TreeNode
+	err = yaml.Unmarshal(exported, &trees)
+	require.Nil(t, err)
+
+	imported, err := FromTree(trees...)
+	require.Nil(t, err)
+	require.Equal(t, nodes, imported)
+}
+
+func TestNodes_MarshalYAML(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	expected, err := yaml.Marshal(nodes.ToTree())
+	require.Nil(t, err)
+
+	res, err := yaml.Marshal(nodes)
+
+	require.Nil(t, err)
+	require.Equal(t, string(expected), string(res))
+}
+
+func TestNode_MarshalYAML_WhenTheNodeIsAValue(t *testing.T) {
+	type envelope struct {
+		Node Node `yaml:"node"`
+	}
+
+	res, err := yaml.Marshal(envelope{Node: NewNode(0x1, []byte{0xab})})
+
+	require.Nil(t, err)
+	require.Equal(t, "node:\n    tag: \"0x1\"\n    length: 1\n    value: ab\n", string(res))
+}
@@ -0,0 +1,150 @@
+package tlv
+
+import "reflect"
+
+// Unmarshal decodes TLV bytes into the struct pointed to by v, using the same `tlv:"<tag>"`
+// struct tags as Marshal. Nodes without a matching field are ignored, as are fields without
+// a matching node. Non-slice fields take the first node with their tag, slices take all of them.
+func (d *decoder) Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return &InvalidTargetError{Type: typeName(v), Expected: "a non-nil pointer to a struct"}
+	}
+
+	var nodes Nodes
+	if len(data) > 0 {
+		var err error
+		if nodes, err = d.DecodeBytes(data); err != nil {
+			return err
+		}
+	}
+
+	return unmarshalStruct(nodes, rv.Elem())
+}
+
+func unmarshalStruct(nodes Nodes, rv reflect.Value) error {
+	fields, err := getStructFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if err = unmarshalField(nodes, field, rv.Field(field.index)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalField(nodes Nodes, field structField, fv reflect.Value) error {
+	if !isRepeated(fv.Type()) {
+		node, ok := nodes.GetFirstByTag(field.tag)
+		if !ok {
+			return nil
+		}
+		return unmarshalValue(&node, field, fv)
+	}
+
+	matches := nodes.GetByTag(field.tag)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	items := reflect.MakeSlice(fv.Type(), len(matches), len(matches))
+	for i := range matches {
+		if err := unmarshalValue(&matches[i], field, items.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	fv.Set(items)
+	return nil
+}
+
+func unmarshalValue(node *Node, field structField, fv reflect.Value) error {
+	if fv.Type() == timeType {
+		date, _ := node.GetDate()
+		fv.Set(reflect.ValueOf(date))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Bool:
+		fv.SetBool(node.GetPaddedBool())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64:
+		return unmarshalNumber(node, field, fv)
+	case reflect.String:
+		fv.SetString(node.GetString())
+	case reflect.Slice:
+		if !isBytes(fv.Type()) {
+			return &UnsupportedTypeError{Field: field.name, Type: fv.Type().String()}
+		}
+		fv.SetBytes(append([]byte{}, node.Value...))
+	case reflect.Ptr:
+		ptr := reflect.New(fv.Type().Elem())
+		if err := unmarshalValue(node, field, ptr.Elem()); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+	case reflect.Struct:
+		return unmarshalNested(node, fv)
+	default:
+		return &UnsupportedTypeError{Field: field.name, Type: fv.Type().String()}
+	}
+
+	return nil
+}
+
+func unmarshalNumber(node *Node, field structField, fv reflect.Value) error {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value := node.GetPaddedInt64()
+		if fv.OverflowInt(value) {
+			return newValueOverflowError(field, value, fv)
+		}
+		fv.SetInt(value)
+	case reflect.Float32, reflect.Float64:
+		value := getFloat(node)
+		if fv.OverflowFloat(value) {
+			return newValueOverflowError(field, value, fv)
+		}
+		fv.SetFloat(value)
+	default:
+		value := node.GetPaddedUint64()
+		if fv.OverflowUint(value) {
+			return newValueOverflowError(field, value, fv)
+		}
+		fv.SetUint(value)
+	}
+
+	return nil
+}
+
+func newValueOverflowError(field structField, value interface{}, fv reflect.Value) error {
+	return &ValueOverflowError{Field: field.name, Value: value, Type: fv.Type().String()}
+}
+
+func unmarshalNested(node *Node, fv reflect.Value) error {
+	var children Nodes
+	if len(node.Value) > 0 {
+		var err error
+		if children, err = node.GetNodes(); err != nil {
+			return err
+		}
+	}
+
+	return unmarshalStruct(children, fv)
+}
+
+// getFloat parses values with 8 or more bytes as float64 and shorter ones as float32.
+func getFloat(node *Node) float64 {
+	if value, ok := node.GetFloat64(); ok {
+		return value
+	}
+
+	value, _ := node.GetFloat32()
+	return float64(value)
+}
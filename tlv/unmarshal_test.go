@@ -0,0 +1,154 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type taggedEnvelope struct {
+	Message taggedMessage `tlv:"0x0001"`
+}
+
+type taggedMessage struct {
+	Notifications []taggedPushNotification `tlv:"0x0101"`
+}
+
+type taggedPushNotification struct {
+	Title     string    `tlv:"0x0102"`
+	ActionID  uint32    `tlv:"0x0103"`
+	Timestamp time.Time `tlv:"0x0104"`
+	Silent    *bool     `tlv:"0x0105"`
+	Ignored   string    `tlv:"-"`
+	Untagged  string
+}
+
+func TestUnmarshal(t *testing.T) {
+	var res taggedEnvelope
+
+	err := Unmarshal(data, &res)
+
+	require.Nil(t, err)
+	require.Equal(t, 2, len(res.Message.Notifications))
+
+	first := res.Message.Notifications[0]
+	require.Equal(t, "Hello there!", first.Title)
+	require.Equal(t, uint32(12345678), first.ActionID)
+	require.Equal(t, time.Unix(1625067296, 0).UTC(), first.Timestamp)
+	require.Nil(t, first.Silent)
+
+	second := res.Message.Notifications[1]
+	require.Equal(t, "You there?", second.Title)
+	require.Equal(t, uint32(240), second.ActionID)
+	require.NotNil(t, second.Silent)
+	require.True(t, *second.Silent)
+}
+
+func TestUnmarshal_WhenTheDataIsEmpty(t *testing.T) {
+	res := taggedPushNotification{Title: "unchanged"}
+
+	err := Unmarshal(nil, &res)
+
+	require.Nil(t, err)
+	require.Equal(t, "unchanged", res.Title)
+}
+
+func TestUnmarshal_WhenTheDataIsCorrupted(t *testing.T) {
+	var res taggedEnvelope
+
+	err := Unmarshal(data[:len(data)-5], &res)
+
+	require.NotNil(t, err)
+}
+
+func TestUnmarshal_WhenTheNestedDataIsCorrupted(t *testing.T) {
+	var res taggedEnvelope
+
+	err := Unmarshal([]byte{0x00, 0x01, 0x00, 0x03, 0x01, 0x01, 0x00}, &res)
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "too short")
+}
+
+func TestUnmarshal_WhenTheTargetIsInvalid(t *testing.T) {
+	scenarios := []interface{}{nil, taggedEnvelope{}, new(string), (*taggedEnvelope)(nil)}
+
+	for _, target := range scenarios {
+		err := Unmarshal(data, target)
+
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "invalid target type")
+	}
+}
+
+func TestUnmarshal_WhenTheValueOverflows(t *testing.T) {
+	var res struct {
+		Value uint8 `tlv:"0x01"`
+	}
+
+	err := Unmarshal([]byte{0x00, 0x01, 0x00, 0x02, 0x01, 0x00}, &res)
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "overflows uint8 on field Value")
+}
+
+func TestUnmarshal_WhenTheFieldTypeIsUnsupported(t *testing.T) {
+	var res struct {
+		Value map[string]int `tlv:"0x01"`
+	}
+
+	err := Unmarshal([]byte{0x00, 0x01, 0x00, 0x00}, &res)
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unsupported type map[string]int on field Value")
+}
+
+func TestUnmarshal_WhenTheStructTagIsInvalid(t *testing.T) {
+	var res struct {
+		Value string `tlv:"abc"`
+	}
+
+	err := Unmarshal([]byte{0x00, 0x01, 0x00, 0x00}, &res)
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "invalid tlv struct tag")
+}
+
+func TestDecoder_Unmarshal_WithCustomConfiguration(t *testing.T) {
+	d := MustCreateDecoder(1, 1, binary.LittleEndian)
+	var res struct {
+		Value uint16 `tlv:"7"`
+		Raw   []byte `tlv:"8"`
+	}
+
+	err := d.Unmarshal([]byte{0x07, 0x02, 0x34, 0x12, 0x08, 0x01, 0xff}, &res)
+
+	require.Nil(t, err)
+	require.Equal(t, uint16(0x1234), res.Value)
+	require.Equal(t, []byte{0xff}, res.Raw)
+}
+
+func TestUnmarshal_WhenTheSignedValueOverflows(t *testing.T) {
+	var res struct {
+		Value int8 `tlv:"0x01"`
+	}
+
+	err := Unmarshal([]byte{0x00, 0x01, 0x00, 0x02, 0x01, 0x00}, &res)
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "value 256 overflows int8 on field Value")
+	require.Zero(t, res.Value)
+}
+
+func TestUnmarshal_WhenTheFloatValueOverflows(t *testing.T) {
+	var res struct {
+		Value float32 `tlv:"0x01"`
+	}
+
+	err := Unmarshal([]byte{0x00, 0x01, 0x00, 0x08, 0x7f, 0xef, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, &res)
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "overflows float32 on field Value")
+}
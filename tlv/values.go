@@ -0,0 +1,85 @@
+package tlv
+
+import (
+	"time"
+
+	"github.com/pauloavelar/go-tlv/tlv/internal/sizes"
+	"github.com/pauloavelar/go-tlv/tlv/internal/utils"
+)
+
+// ValueOption configures how typed node constructors, such as [Decoder.NewUint32Node], encode numbers.
+type ValueOption func(*valueConfig)
+
+type valueConfig struct {
+	minimalWidth bool
+}
+
+// WithMinimalWidth encodes numbers with as few bytes as needed (at least one) instead of the size of
+// their type, e.g. 240 as a single byte. Such values are read by the GetPadded getters.
+func WithMinimalWidth() ValueOption {
+	return func(c *valueConfig) {
+		c.minimalWidth = true
+	}
+}
+
+// NewBoolNode creates a [Node] with a 1-byte boolean.
+func (d *decoder) NewBoolNode(tag Tag, value bool) Node {
+	if value {
+		return d.NewNode(tag, []byte{1})
+	}
+
+	return d.NewNode(tag, []byte{0})
+}
+
+// NewUint8Node creates a [Node] with a 1-byte unsigned integer.
+func (d *decoder) NewUint8Node(tag Tag, value uint8) Node {
+	return d.NewNode(tag, []byte{value})
+}
+
+// NewUint16Node creates a [Node] with a 2-byte unsigned integer in the [Decoder] byte order.
+func (d *decoder) NewUint16Node(tag Tag, value uint16, options ...ValueOption) Node {
+	return d.newUintNode(tag, uint64(value), sizes.Uint16, options)
+}
+
+// NewUint32Node creates a [Node] with a 4-byte unsigned integer in the [Decoder] byte order.
+func (d *decoder) NewUint32Node(tag Tag, value uint32, options ...ValueOption) Node {
+	return d.newUintNode(tag, uint64(value), sizes.Uint32, options)
+}
+
+// NewUint64Node creates a [Node] with an 8-byte unsigned integer in the [Decoder] byte order.
+func (d *decoder) NewUint64Node(tag Tag, value uint64, options ...ValueOption) Node {
+	return d.newUintNode(tag, value, sizes.Uint64, options)
+}
+
+// NewStringNode creates a [Node] with the text as UTF-8.
+func (d *decoder) NewStringNode(tag Tag, value string) Node {
+	return d.NewNode(tag, []byte(value))
+}
+
+// NewDateNode creates a [Node] with the date as Unix seconds in 8 bytes, as read by GetDate.
+func (d *decoder) NewDateNode(tag Tag, value time.Time, options ...ValueOption) Node {
+	return d.newUintNode(tag, uint64(value.Unix()), sizes.Uint64, options)
+}
+
+// NewNestedNode creates a [Node] whose value holds the encoded children.
+func (d *decoder) NewNestedNode(tag Tag, children ...Node) (Node, error) {
+	value, err := d.encoder.EncodeNodes(children)
+	if err != nil {
+		return Node{}, err
+	}
+
+	return d.NewNode(tag, value), nil
+}
+
+func (d *decoder) newUintNode(tag Tag, value uint64, size int, options []ValueOption) Node {
+	var config valueConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	if config.minimalWidth {
+		size = utils.ByteCount(value)
+	}
+
+	return d.NewNode(tag, d.encoder.marshalUint(value, size))
+}
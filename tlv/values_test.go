@@ -0,0 +1,90 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNestedNode_WithMinimalWidth(t *testing.T) {
+	first, err := NewNestedNode(tagPushNotification,
+		NewStringNode(tagTitle, "Hello there!"),
+		NewUint32Node(tagActionID, 12345678, WithMinimalWidth()),
+		NewDateNode(tagTimestamp, time.Unix(1625067296, 0), WithMinimalWidth()),
+	)
+	require.Nil(t, err)
+
+	second, err := NewNestedNode(tagPushNotification,
+		NewStringNode(tagTitle, "You there?"),
+		NewUint64Node(tagActionID, 240, WithMinimalWidth()),
+		NewBoolNode(tagSilent, true),
+		NewDateNode(tagTimestamp, time.Unix(1614571323, 0), WithMinimalWidth()),
+	)
+	require.Nil(t, err)
+
+	message, err := NewNestedNode(tagMessage, first, second)
+	require.Nil(t, err)
+
+	res, err := EncodeNode(message)
+	require.Nil(t, err)
+	require.Equal(t, data, res)
+}
+
+func TestDecoder_NewUintNode(t *testing.T) {
+	scenarios := map[binary.ByteOrder][]byte{
+		binary.BigEndian:    {0x12, 0x34, 0x00, 0x00, 0x12, 0x34, 0x12, 0x34},
+		binary.LittleEndian: {0x34, 0x12, 0x34, 0x12, 0x00, 0x00, 0x34, 0x12},
+	}
+
+	for byteOrder, expected := range scenarios {
+		decoder := MustCreateDecoder(2, 2, byteOrder)
+
+		nodes := Nodes{
+			decoder.NewUint16Node(0x01, 0x1234),
+			decoder.NewUint32Node(0x02, 0x1234),
+			decoder.NewUint16Node(0x03, 0x1234, WithMinimalWidth()),
+		}
+
+		require.Equal(t, expected[:2], nodes[0].Value)
+		require.Equal(t, expected[2:6], nodes[1].Value)
+		require.Equal(t, expected[6:], nodes[2].Value)
+
+		for i := range nodes {
+			require.Equal(t, uint64(0x1234), nodes[i].GetPaddedUint64())
+		}
+	}
+}
+
+func TestDecoder_NewTypedNodes(t *testing.T) {
+	date := time.Date(2021, 6, 30, 12, 34, 56, 0, time.UTC)
+
+	boolNode := NewBoolNode(tagSilent, false)
+	uint8Node := NewUint8Node(0x01, 0xab)
+	uint64Node := NewUint64Node(0x02, 0)
+	minimalNode := NewUint64Node(0x02, 0, WithMinimalWidth())
+	dateNode := NewDateNode(tagTimestamp, date)
+
+	value, ok := boolNode.GetBool()
+	require.True(t, ok)
+	require.False(t, value)
+	require.Equal(t, uint8(0xab), uint8Node.GetPaddedUint8())
+	require.Len(t, uint64Node.Value, 8)
+	require.Equal(t, []byte{0x00}, minimalNode.Value)
+	require.Equal(t, []byte("title"), NewStringNode(tagTitle, "title").Value)
+
+	res, ok := dateNode.GetDate()
+	require.True(t, ok)
+	require.Equal(t, date, res)
+	require.Len(t, dateNode.Value, 8)
+}
+
+func TestDecoder_NewNestedNode_WhenChildrenCannotBeEncoded(t *testing.T) {
+	decoder := MustCreateDecoder(1, 1, binary.BigEndian)
+
+	_, err := decoder.NewNestedNode(0x01, decoder.NewNode(0x100, nil))
+
+	require.True(t, errors.Is(err, ErrOverflow))
+}
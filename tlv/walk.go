@@ -0,0 +1,90 @@
+package tlv
+
+import "errors"
+
+// Sentinel values returned by a [WalkFunc] to control the traversal. They are never returned by Walk.
+var (
+	SkipChildren = errors.New("skip children") // Does not descend into the value of the node.
+	SkipAll      = errors.New("skip all")      // Stops the walk.
+	Descend      = errors.New("descend")       // Descends into the value of the node even if it is not a container.
+)
+
+// WalkFunc is called by [Walk] for every node, with the tags of its parent nodes and its depth
+// (1 for nodes without parents). The path must not be modified. Returning nil descends into the
+// node value only if it is a container, while SkipChildren, SkipAll and Descend change that
+// decision. Any other error stops the walk and is returned by Walk.
+type WalkFunc func(node *Node, path []Tag, depth int) error
+
+type walker struct {
+	fn         WalkFunc
+	containers map[Tag]struct{}
+}
+
+// Walk visits the nodes depth-first, calling fn for each node before its nested nodes.
+// Containers are the nodes whose values decode as TLV, unless the schema defines them as not
+// nested (the same rule used by [Nodes.Dump]). Values that fail to decode are visited as leaves,
+// except when fn returns Descend or MaxNodes is exceeded, in which case the decoding error is returned.
+func Walk(nodes Nodes, fn WalkFunc) error {
+	return walk(&walker{fn: fn}, nodes)
+}
+
+// WalkContainers works as [Walk], but only the nodes with one of the container tags are containers.
+// Decoding errors in the values of containers are returned.
+func WalkContainers(nodes Nodes, containers []Tag, fn WalkFunc) error {
+	w := &walker{fn: fn, containers: make(map[Tag]struct{}, len(containers))}
+	for _, tag := range containers {
+		w.containers[tag] = struct{}{}
+	}
+
+	return walk(w, nodes)
+}
+
+func walk(w *walker, nodes Nodes) error {
+	if err := w.walkNodes(nodes); err != SkipAll {
+		return err
+	}
+
+	return nil
+}
+
+func (w *walker) walkNodes(nodes Nodes) error {
+	for i := range nodes {
+		if err := w.visit(&nodes[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *walker) visit(node *Node) error {
+	children, err := w.getChildren(node, w.fn(node, node.path, len(node.path)+1))
+	if err != nil {
+		return err
+	}
+
+	return w.walkNodes(children)
+}
+
+// getChildren returns the nested nodes to visit according to the result of the WalkFunc.
+func (w *walker) getChildren(node *Node, result error) (Nodes, error) {
+	switch {
+	case result == SkipChildren:
+		return nil, nil
+	case result != nil && result != Descend:
+		return nil, result
+	case len(node.Value) == 0:
+		return nil, nil
+	case result == Descend:
+		return node.GetNodes()
+	case w.containers == nil:
+		children, _, err := node.decodeNested()
+		return children, err
+	}
+
+	if _, ok := w.containers[node.Tag]; !ok {
+		return nil, nil
+	}
+
+	return node.GetNodes()
+}
@@ -0,0 +1,149 @@
+package tlv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type visit struct {
+	tag   Tag
+	path  []Tag
+	depth int
+}
+
+func collectVisits(visits *[]visit, result func(node *Node) error) WalkFunc {
+	return func(node *Node, path []Tag, depth int) error {
+		*visits = append(*visits, visit{tag: node.Tag, path: copyPath(path), depth: depth})
+		if result == nil {
+			return nil
+		}
+		return result(node)
+	}
+}
+
+func getVisitedTags(visits []visit) []Tag {
+	res := make([]Tag, len(visits))
+	for i := range visits {
+		res[i] = visits[i].tag
+	}
+
+	return res
+}
+
+func TestWalk(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	var visits []visit
+	err = Walk(nodes, collectVisits(&visits, nil))
+
+	require.Nil(t, err)
+	require.Equal(t, []Tag{
+		tagMessage,
+		tagPushNotification, tagTitle, tagActionID, tagTimestamp,
+		tagPushNotification, tagTitle, tagActionID, tagSilent, tagTimestamp,
+	}, getVisitedTags(visits))
+	require.Equal(t, visit{tag: tagMessage, depth: 1}, visits[0])
+	require.Equal(t, visit{tag: tagPushNotification, path: []Tag{tagMessage}, depth: 2}, visits[1])
+	require.Equal(t, visit{tag: tagTitle, path: []Tag{tagMessage, tagPushNotification}, depth: 3}, visits[2])
+}
+
+func TestWalk_WhenTheCallbackSkipsChildren(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	var visits []visit
+	err = Walk(nodes, collectVisits(&visits, func(node *Node) error {
+		if node.Tag == tagPushNotification {
+			return SkipChildren
+		}
+		return nil
+	}))
+
+	require.Nil(t, err)
+	require.Equal(t, []Tag{tagMessage, tagPushNotification, tagPushNotification}, getVisitedTags(visits))
+}
+
+func TestWalk_WhenTheCallbackSkipsAll(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	var visits []visit
+	err = Walk(nodes, collectVisits(&visits, func(node *Node) error {
+		if node.Tag == tagTitle {
+			return SkipAll
+		}
+		return nil
+	}))
+
+	require.Nil(t, err)
+	require.Equal(t, []Tag{tagMessage, tagPushNotification, tagTitle}, getVisitedTags(visits))
+}
+
+func TestWalk_WhenTheCallbackFails(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	expected := errors.New("forcing walk error")
+	err = Walk(nodes, func(node *Node, _ []Tag, _ int) error {
+		return expected
+	})
+
+	require.Equal(t, expected, err)
+}
+
+func TestWalk_WhenTheCallbackDescends(t *testing.T) {
+	value, err := EncodeNode(NewNode(tagActionID, []byte{0x01}))
+	require.Nil(t, err)
+
+	schema := MustCreateSchema(TagDefinition{Tag: tagTitle, Type: TypeString})
+	nodes := Nodes{stdDecoder.WithSchema(schema).NewNode(tagTitle, value)}
+
+	var visits []visit
+	err = Walk(nodes, collectVisits(&visits, nil))
+	require.Nil(t, err)
+	require.Equal(t, []Tag{tagTitle}, getVisitedTags(visits))
+
+	visits = nil
+	err = Walk(nodes, collectVisits(&visits, func(node *Node) error {
+		if node.Tag == tagTitle {
+			return Descend
+		}
+		return nil
+	}))
+	require.Nil(t, err)
+	require.Equal(t, []Tag{tagTitle, tagActionID}, getVisitedTags(visits))
+}
+
+func TestWalk_WhenTheCallbackDescendsIntoAnInvalidValue(t *testing.T) {
+	nodes := Nodes{NewNode(tagTitle, []byte{0x01}), NewNode(tagActionID, nil)}
+
+	err := Walk(nodes, func(node *Node, _ []Tag, _ int) error {
+		return Descend
+	})
+
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+}
+
+func TestWalkContainers(t *testing.T) {
+	nodes, err := DecodeBytes(data)
+	require.Nil(t, err)
+
+	var visits []visit
+	err = WalkContainers(nodes, []Tag{tagMessage}, collectVisits(&visits, nil))
+
+	require.Nil(t, err)
+	require.Equal(t, []Tag{tagMessage, tagPushNotification, tagPushNotification}, getVisitedTags(visits))
+}
+
+func TestWalkContainers_WhenAContainerIsInvalid(t *testing.T) {
+	nodes := Nodes{NewNode(tagMessage, []byte{0x01})}
+
+	err := WalkContainers(nodes, []Tag{tagMessage}, func(*Node, []Tag, int) error {
+		return nil
+	})
+
+	require.True(t, errors.Is(err, ErrMessageTooShort))
+}